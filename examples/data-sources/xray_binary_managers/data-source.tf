@@ -0,0 +1,16 @@
+data "xray_binary_managers" "all" {}
+
+resource "xray_watch" "all-repos" {
+  name   = "all-repos-watch"
+  active = true
+
+  watch_resource {
+    type       = "all-repos"
+    bin_mgr_id = data.xray_binary_managers.all.binary_managers[0].id
+  }
+
+  assigned_policy {
+    name = "security-policy"
+    type = "security"
+  }
+}
@@ -0,0 +1,9 @@
+data "xray_db_sync_status" "status" {}
+
+output "db_sync_state" {
+  value = data.xray_db_sync_status.status.state
+}
+
+output "db_last_successful_sync_time" {
+  value = data.xray_db_sync_status.status.last_successful_sync_time
+}
@@ -0,0 +1,14 @@
+data "xray_ignore_rules" "expiring" {
+  expires_before = timeadd(timestamp(), "720h")
+}
+
+output "ignore_rules_expiring_in_30_days" {
+  value = [
+    for rule in data.xray_ignore_rules.expiring.ignore_rules : {
+      id              = rule.id
+      notes           = rule.notes
+      author          = rule.author
+      expiration_date = rule.expiration_date
+    }
+  ]
+}
@@ -0,0 +1,8 @@
+resource "xray_basic_settings" "settings" {
+  enabled                         = true
+  allow_download_when_unavailable = false
+  allow_blocked_download          = false
+  block_unscanned_timeout         = 60
+  max_file_size                   = 1000
+  default_retention_in_days       = 90
+}
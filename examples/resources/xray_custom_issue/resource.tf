@@ -0,0 +1,25 @@
+resource "xray_custom_issue" "internal-advisory" {
+  name         = "ACME-2022-0001"
+  description  = "Remote code execution in the ACME template engine."
+  summary      = "RCE in acme-templates"
+  type         = "Security"
+  package_type = "maven"
+  severity     = "High"
+
+  component {
+    id                  = "com.acme:acme-templates"
+    vulnerable_versions = ["(,2.4.1)"]
+    fixed_versions      = ["2.4.1"]
+  }
+
+  cve {
+    cve     = "CVE-2022-12345"
+    cvss_v3 = "8.1/CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:H"
+  }
+
+  source {
+    id   = "ACME-SA-2022-01"
+    name = "ACME security advisory"
+    url  = "https://security.acme.example.com/advisories/2022-01"
+  }
+}
@@ -0,0 +1,28 @@
+resource "xray_custom_license" "acme-eula" {
+  name      = "ACME-EULA-1.0"
+  full_name = "ACME End User License Agreement 1.0"
+  url       = "https://acme.example.com/eula-1.0.html"
+  category  = "Proprietary"
+  alias     = ["(?i)acme.*eula.*1\\.0"]
+}
+
+resource "xray_license_policy" "license-policy" {
+  name = "license-policy"
+  type = "license"
+
+  rule {
+    name     = "banned-licenses"
+    priority = 1
+
+    criteria {
+      banned_licenses = [xray_custom_license.acme-eula.name]
+    }
+
+    actions {
+      block_download {
+        unscanned = false
+        active    = true
+      }
+    }
+  }
+}
@@ -0,0 +1,14 @@
+resource "xray_db_sync" "full" {
+  full_sync           = true
+  wait_for_completion = true
+
+  # Changing any of the values triggers a new DB sync
+  triggers = {
+    offline_update_path = xray_offline_update_source.air-gapped.path
+    revision            = "1"
+  }
+
+  timeouts {
+    create = "4h"
+  }
+}
@@ -0,0 +1,34 @@
+resource "xray_ignore_rule" "ignore-log4j" {
+  notes           = "False positive, the vulnerable class is not used"
+  expiration_date = "2024-04-05"
+  cves            = ["CVE-2021-44228"]
+
+  artifact {
+    name    = "example-app.jar"
+    version = "1.0.0"
+    path    = "libs-release-local/org/example/"
+  }
+
+  # 'notes' and 'expiration_date' are updated in place. Changes of the other
+  # attributes replace the rule, the replacement is created first.
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+# Ignore the vulnerabilities of the old lodash versions in the legacy npm repository.
+# The range and the pattern are expanded to the concrete versions and artifacts during the plan.
+resource "xray_ignore_rule" "legacy-lodash" {
+  notes           = "Legacy packages, lodash is upgraded in the new repository"
+  expires_in_days = 90
+  vulnerabilities = ["any"]
+
+  component {
+    name          = "npm://lodash"
+    version_range = "< 4.17.21"
+  }
+
+  artifact_pattern {
+    path_pattern = "npm-legacy/**"
+  }
+}
@@ -0,0 +1,24 @@
+resource "xray_indexed_resources" "default" {
+  bin_mgr_id = "default"
+
+  repos                  = ["docker-local", "npm-remote"]
+  builds                 = ["my-build"]
+  build_include_patterns = ["release-*/**"]
+  build_exclude_patterns = ["release-*/test/**"]
+  release_bundles        = ["my-release-bundle"]
+}
+
+resource "xray_watch" "repos" {
+  name   = "indexed-repos-watch"
+  active = true
+
+  watch_resource {
+    type = "repository"
+    name = tolist(xray_indexed_resources.default.repos)[0]
+  }
+
+  assigned_policy {
+    name = "security-policy"
+    type = "security"
+  }
+}
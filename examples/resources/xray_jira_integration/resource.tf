@@ -0,0 +1,16 @@
+resource "xray_jira_integration" "jira" {
+  name              = "jira-cloud"
+  url               = "https://example.atlassian.net"
+  installation_type = "cloud"
+  auth_type         = "basic"
+  username          = "xray@example.com"
+  password          = var.jira_api_token
+  projects          = ["SEC"]
+  issue_types       = ["Bug"]
+  labels            = ["xray"]
+
+  field_mapping {
+    xray_field = "severity"
+    jira_field = "customfield_10010"
+  }
+}
@@ -0,0 +1,4 @@
+resource "xray_offline_update_source" "air-gapped" {
+  path            = "/opt/jfrog/xray/offline-updates"
+  component_types = ["vulnerabilities", "components", "licenses"]
+}
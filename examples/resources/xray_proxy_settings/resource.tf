@@ -0,0 +1,7 @@
+resource "xray_proxy_settings" "proxy" {
+  url            = "http://proxy.mycompany.com"
+  port           = 8080
+  username       = "proxy-user"
+  password       = var.proxy_password
+  no_proxy_hosts = ["localhost", "*.mycompany.com"]
+}
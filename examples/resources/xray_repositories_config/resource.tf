@@ -0,0 +1,44 @@
+resource "xray_repositories_config" "docker-repos" {
+  repo_names           = ["example-repo-local"]
+  repo_include_pattern = "^docker-"
+  repo_exclude_pattern = "-test$"
+  parallelism          = 10
+  rate_limit           = 20
+
+  config {
+    retention_in_days = 90
+  }
+}
+
+resource "xray_repositories_config" "npm-repos" {
+  repo_include_pattern = "^npm-"
+
+  paths_config {
+    pattern {
+      include             = "core/**"
+      exclude             = "core/internal/**"
+      index_new_artifacts = true
+      retention_in_days   = 60
+    }
+
+    all_other_artifacts {
+      index_new_artifacts = true
+      retention_in_days   = 30
+    }
+  }
+}
+
+resource "xray_repositories_config" "defaults-per-package-type" {
+  repo_include_pattern = ".*"
+  project_key          = "myproj"
+
+  package_type_config {
+    package_type      = "docker"
+    retention_in_days = 30
+  }
+
+  package_type_config {
+    package_type      = "maven"
+    retention_in_days = 365
+  }
+}
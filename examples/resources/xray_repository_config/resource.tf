@@ -1,5 +1,6 @@
 resource "xray_repository_config" "xray-repo-config-pattern" {
-  repo_name = "example-repo-local"
+  repo_name  = "example-repo-local"
+  test_paths = ["core/lib/app.jar", "core/internal/app.jar", "docs/readme.md"]
 
   paths_config {
     pattern {
@@ -30,4 +31,53 @@ resource "xray_repository_config" "xray-repo-config" {
     vuln_contextual_analysis = true
     retention_in_days        = 90
   }
-}
\ No newline at end of file
+}
+
+resource "xray_repository_config" "xray-repo-config-restore" {
+  repo_name          = "example-repo-local"
+  restore_on_destroy = true
+
+  config {
+    retention_in_days = 30
+  }
+}
+
+resource "xray_repository_config" "xray-repo-config-default" {
+  repo_name = "example-repo-local"
+
+  config {
+    retention_in_days = 30
+  }
+
+  default_config {
+    retention_in_days = 90
+  }
+}
+
+resource "xray_repository_config" "xray-repo-config-project" {
+  repo_name   = "myproj-example-repo-local"
+  project_key = "myproj"
+
+  config {
+    retention_in_days = 90
+  }
+}
+
+locals {
+  package_type_configs = {
+    docker = 30
+    maven  = 365
+  }
+}
+
+resource "xray_repository_config" "xray-repo-config-package-type" {
+  repo_name = "example-docker-local"
+
+  dynamic "package_type_config" {
+    for_each = local.package_type_configs
+    content {
+      package_type      = package_type_config.key
+      retention_in_days = package_type_config.value
+    }
+  }
+}
@@ -0,0 +1,35 @@
+resource "xray_webhook" "violations-webhook" {
+  name        = "violations-webhook"
+  url         = "https://ci.example.com/hooks/xray"
+  description = "Notifies the CI server about new violations"
+  use_proxy   = false
+  user_name   = "xray"
+  password    = var.webhook_password
+
+  headers = {
+    X-Custom-Header = "value"
+  }
+}
+
+resource "xray_security_policy" "security-policy" {
+  name = "security-policy"
+  type = "security"
+
+  rule {
+    name     = "rule-name-severity"
+    priority = 1
+
+    criteria {
+      min_severity = "High"
+    }
+
+    actions {
+      webhooks = [xray_webhook.violations-webhook.name]
+
+      block_download {
+        unscanned = true
+        active    = true
+      }
+    }
+  }
+}
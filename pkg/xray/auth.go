@@ -0,0 +1,232 @@
+package xray
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/client"
+)
+
+const defaultOIDCTokenEnv = "JFROG_OIDC_TOKEN"
+
+type OIDCTokenExchange struct {
+	GrantType        string `json:"grant_type"`
+	SubjectTokenType string `json:"subject_token_type"`
+	SubjectToken     string `json:"subject_token"`
+	ProviderName     string `json:"provider_name"`
+}
+
+type OIDCTokenExchangeResult struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// readOIDCIdToken reads the ID token issued by the CI from the file, or from the environment variable, if the file is not set
+func readOIDCIdToken(tokenFile, tokenEnv string) (string, error) {
+	if len(tokenFile) > 0 {
+		token, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the OIDC ID token from the file '%s': %w", tokenFile, err)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+
+	token := strings.TrimSpace(os.Getenv(tokenEnv))
+	if len(token) == 0 {
+		return "", fmt.Errorf("the OIDC ID token is not found, set the '%s' environment variable or the 'oidc_token_file' attribute", tokenEnv)
+	}
+
+	return token, nil
+}
+
+// exchangeOIDCToken exchanges the ID token for a short-lived JFrog access token, using the OIDC integration configured in the JFrog Platform
+func exchangeOIDCToken(client *resty.Client, providerName, idToken string) (OIDCTokenExchangeResult, error) {
+	result := OIDCTokenExchangeResult{}
+
+	_, err := client.R().
+		SetBody(OIDCTokenExchange{
+			GrantType:        "urn:ietf:params:oauth:grant-type:token-exchange",
+			SubjectTokenType: "urn:ietf:params:oauth:token-type:id_token",
+			SubjectToken:     idToken,
+			ProviderName:     providerName,
+		}).
+		SetResult(&result).
+		Post("access/api/v1/oidc/token")
+	if err != nil {
+		return result, fmt.Errorf("failed to exchange the OIDC ID token using the provider '%s': %w", providerName, err)
+	}
+	if len(result.AccessToken) == 0 {
+		return result, fmt.Errorf("the OIDC token exchange using the provider '%s' returned no access token", providerName)
+	}
+
+	return result, nil
+}
+
+const (
+	authMethodOIDC        = "oidc"
+	authMethodAccessToken = "access_token"
+	authMethodAPIKey      = "api_key"
+	authMethodBasic       = "basic"
+)
+
+// The token is exchanged again, if it expires sooner than the margin
+const oidcTokenRefreshMargin = time.Minute
+
+// getAuthMethod returns the authentication method used by the provider. The precedence is the OIDC token exchange,
+// the access token, the API key, then the username and password.
+func getAuthMethod(d *schema.ResourceData) (string, error) {
+	switch {
+	case len(d.Get("oidc_provider_name").(string)) > 0:
+		return authMethodOIDC, nil
+	case len(d.Get("access_token").(string)) > 0:
+		return authMethodAccessToken, nil
+	case len(d.Get("api_key").(string)) > 0:
+		return authMethodAPIKey, nil
+	case len(d.Get("username").(string)) > 0 && len(d.Get("password").(string)) > 0:
+		return authMethodBasic, nil
+	}
+
+	return "", fmt.Errorf("no authentication details supplied, set one of 'access_token', 'oidc_provider_name', 'api_key', or 'username' and 'password'")
+}
+
+// OIDCTokenError is returned, when the access token can't be obtained for the request. The exchange client retries
+// on its own, so the request failed with this error is not retried.
+type OIDCTokenError struct {
+	Err error
+}
+
+func (e OIDCTokenError) Error() string {
+	return e.Err.Error()
+}
+
+func (e OIDCTokenError) Unwrap() error {
+	return e.Err
+}
+
+// OIDCTokenSource keeps the access token exchanged for the OIDC ID token, and exchanges the ID token again,
+// when the access token is about to expire or is rejected by the JFrog Platform.
+type OIDCTokenSource struct {
+	mutex        sync.Mutex
+	client       *resty.Client
+	providerName string
+	tokenFile    string
+	tokenEnv     string
+	accessToken  string
+	// Zero, if the access token doesn't expire
+	expiresAt   time.Time
+	exchangedAt time.Time
+}
+
+// token returns the access token, which is exchanged again, if it expired or was invalidated
+func (s *OIDCTokenSource) token(ctx context.Context) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.accessToken) > 0 && (s.expiresAt.IsZero() || time.Now().Add(oidcTokenRefreshMargin).Before(s.expiresAt)) {
+		return s.accessToken, nil
+	}
+
+	// The ID token is read again, as the CI may have refreshed it
+	idToken, err := readOIDCIdToken(s.tokenFile, s.tokenEnv)
+	if err != nil {
+		return "", OIDCTokenError{Err: err}
+	}
+
+	result, err := exchangeOIDCToken(s.client, s.providerName, idToken)
+	if err != nil {
+		return "", OIDCTokenError{Err: err}
+	}
+	tflog.Info(ctx, fmt.Sprintf("Exchanged the OIDC ID token using the provider '%s', the access token expires in %d seconds", s.providerName, result.ExpiresIn))
+
+	s.accessToken = result.AccessToken
+	s.exchangedAt = time.Now()
+	s.expiresAt = time.Time{}
+	if result.ExpiresIn > 0 {
+		s.expiresAt = s.exchangedAt.Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+
+	return s.accessToken, nil
+}
+
+// invalidate drops the rejected access token, so it's exchanged again on the retry, and reports whether the request
+// should be retried. The token, which was just exchanged, is kept, as the request would be rejected again.
+func (s *OIDCTokenSource) invalidate(accessToken string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// The token was already exchanged again by another request
+	if s.accessToken != accessToken {
+		return true
+	}
+	if time.Since(s.exchangedAt) <= oidcTokenRefreshMargin {
+		return false
+	}
+
+	s.accessToken = ""
+	return true
+}
+
+// addOIDCAuth exchanges the OIDC ID token, and sets the access token to every request
+func addOIDCAuth(ctx context.Context, restyBase *resty.Client, tokenSource *OIDCTokenSource) (*resty.Client, error) {
+	if _, err := tokenSource.token(ctx); err != nil {
+		return nil, err
+	}
+
+	return restyBase.
+		OnBeforeRequest(func(_ *resty.Client, request *resty.Request) error {
+			accessToken, err := tokenSource.token(request.Context())
+			if err != nil {
+				return err
+			}
+			request.SetAuthToken(accessToken)
+			return nil
+		}).
+		AddRetryCondition(func(response *resty.Response, err error) bool {
+			if response != nil && response.StatusCode() == http.StatusUnauthorized {
+				return tokenSource.invalidate(response.Request.Token)
+			}
+			var tokenError OIDCTokenError
+			if errors.As(err, &tokenError) {
+				return false
+			}
+			// The condition replaces the default one, which retries only the failed connections
+			return err != nil && (response == nil || response.RawResponse == nil)
+		}), nil
+}
+
+// addAuth configures the client authentication, see getAuthMethod for the precedence
+func addAuth(ctx context.Context, restyBase *resty.Client, d *schema.ResourceData) (*resty.Client, error) {
+	authMethod, err := getAuthMethod(d)
+	if err != nil {
+		return nil, err
+	}
+
+	switch authMethod {
+	case authMethodOIDC:
+		// The token is exchanged by a separate client, so the exchange requests don't pass through the authentication of the provider client
+		exchangeClient, err := client.Build(restyBase.HostURL, productId)
+		if err != nil {
+			return nil, err
+		}
+
+		return addOIDCAuth(ctx, restyBase, &OIDCTokenSource{
+			client:       exchangeClient,
+			providerName: d.Get("oidc_provider_name").(string),
+			tokenFile:    d.Get("oidc_token_file").(string),
+			tokenEnv:     d.Get("oidc_token_env").(string),
+		})
+	case authMethodAccessToken, authMethodAPIKey:
+		return client.AddAuth(restyBase, d.Get("api_key").(string), d.Get("access_token").(string))
+	default:
+		return restyBase.SetBasicAuth(d.Get("username").(string), d.Get("password").(string)), nil
+	}
+}
@@ -0,0 +1,235 @@
+package xray
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/client"
+)
+
+func TestReadOIDCIdToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "id_token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_OIDC_TOKEN", "env-token")
+
+	token, err := readOIDCIdToken(tokenFile, "TEST_OIDC_TOKEN")
+	if err != nil || token != "file-token" {
+		t.Errorf("expected the token from the file, got %q, %v", token, err)
+	}
+
+	token, err = readOIDCIdToken("", "TEST_OIDC_TOKEN")
+	if err != nil || token != "env-token" {
+		t.Errorf("expected the token from the environment variable, got %q, %v", token, err)
+	}
+
+	if _, err := readOIDCIdToken("", "TEST_OIDC_TOKEN_NOT_SET"); err == nil {
+		t.Error("expected an error, if the environment variable is not set")
+	}
+
+	if _, err := readOIDCIdToken(filepath.Join(t.TempDir(), "missing"), "TEST_OIDC_TOKEN"); err == nil {
+		t.Error("expected an error, if the file doesn't exist")
+	}
+}
+
+func TestExchangeOIDCToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/access/api/v1/oidc/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		request := OIDCTokenExchange{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if request.ProviderName != "github" || request.SubjectToken != "id-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OIDCTokenExchangeResult{AccessToken: "access-token", ExpiresIn: 600})
+	}))
+	defer server.Close()
+
+	restyClient, err := client.Build(server.URL, productId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restyClient.SetRetryCount(0)
+
+	result, err := exchangeOIDCToken(restyClient, "github", "id-token")
+	if err != nil || result.AccessToken != "access-token" || result.ExpiresIn != 600 {
+		t.Errorf("expected the access token, got %v, %v", result, err)
+	}
+
+	if _, err := exchangeOIDCToken(restyClient, "github", "invalid-token"); err == nil {
+		t.Error("expected an error for the rejected ID token")
+	}
+}
+
+func TestGetAuthMethod(t *testing.T) {
+	for _, env := range []string{"XRAY_ACCESS_TOKEN", "JFROG_ACCESS_TOKEN", "XRAY_API_KEY", "JFROG_API_KEY", "XRAY_USERNAME", "JFROG_USERNAME", "XRAY_PASSWORD", "JFROG_PASSWORD"} {
+		t.Setenv(env, "")
+	}
+
+	testCases := []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{
+			config: map[string]interface{}{
+				"oidc_provider_name": "github",
+				"access_token":       "token",
+				"api_key":            "key",
+				"username":           "admin",
+				"password":           "password",
+			},
+			expected: authMethodOIDC,
+		},
+		{
+			config: map[string]interface{}{
+				"access_token": "token",
+				"api_key":      "key",
+				"username":     "admin",
+				"password":     "password",
+			},
+			expected: authMethodAccessToken,
+		},
+		{
+			config: map[string]interface{}{
+				"api_key":  "key",
+				"username": "admin",
+				"password": "password",
+			},
+			expected: authMethodAPIKey,
+		},
+		{
+			config: map[string]interface{}{
+				"username": "admin",
+				"password": "password",
+			},
+			expected: authMethodBasic,
+		},
+	}
+
+	for _, testCase := range testCases {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, testCase.config)
+		if authMethod, err := getAuthMethod(d); err != nil || authMethod != testCase.expected {
+			t.Errorf("expected auth method %q for %v, got %q, %v", testCase.expected, testCase.config, authMethod, err)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"username": "admin"})
+	if _, err := getAuthMethod(d); err == nil {
+		t.Error("expected an error without the password")
+	}
+}
+
+func TestOIDCTokenRefresh(t *testing.T) {
+	t.Setenv("TEST_OIDC_TOKEN", "id-token")
+
+	var exchanges int
+	var expiresIn int
+	failExchange := false
+	revokedTokens := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/access/api/v1/oidc/token":
+			exchanges++
+			if failExchange {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(OIDCTokenExchangeResult{AccessToken: fmt.Sprintf("access-token-%d", exchanges), ExpiresIn: expiresIn})
+		case "/xray/api/v1/system/ping":
+			if token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); revokedTokens[token] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(XrayPing{Status: "pong"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	exchangeClient, err := client.Build(server.URL, productId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchangeClient.SetRetryCount(0)
+	restyClient, err := client.Build(server.URL, productId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restyClient.SetRetryCount(1)
+
+	tokenSource := &OIDCTokenSource{
+		client:       exchangeClient,
+		providerName: "github",
+		tokenEnv:     "TEST_OIDC_TOKEN",
+	}
+
+	// The token, which expires within the refresh margin, is exchanged before every request
+	expiresIn = 30
+	if _, err := addOIDCAuth(context.Background(), restyClient, tokenSource); err != nil {
+		t.Fatal(err)
+	}
+	if err := pingXray(restyClient); err != nil || exchanges != 2 {
+		t.Errorf("expected the expiring token to be exchanged again, got %d exchanges, %v", exchanges, err)
+	}
+
+	// The token, which is rejected, is exchanged again on the retry
+	expiresIn = 3600
+	if err := pingXray(restyClient); err != nil || exchanges != 3 {
+		t.Fatalf("expected the valid token to be exchanged, got %d exchanges, %v", exchanges, err)
+	}
+	revokedTokens["access-token-3"] = true
+	tokenSource.exchangedAt = time.Now().Add(-2 * oidcTokenRefreshMargin)
+	if err := pingXray(restyClient); err != nil || exchanges != 4 {
+		t.Errorf("expected the rejected token to be exchanged again, got %d exchanges, %v", exchanges, err)
+	}
+
+	// The token, which was just exchanged, isn't exchanged again, when it's rejected
+	revokedTokens["access-token-4"] = true
+	if err := pingXray(restyClient); err == nil || exchanges != 4 {
+		t.Errorf("expected the rejected fresh token to fail the request, got %d exchanges, %v", exchanges, err)
+	}
+
+	// The request, which can't get the token, isn't retried
+	failExchange = true
+	tokenSource.accessToken = ""
+	var tokenError OIDCTokenError
+	if err := pingXray(restyClient); !errors.As(err, &tokenError) || exchanges != 5 {
+		t.Errorf("expected the failed exchange not to be retried, got %d exchanges, %v", exchanges, err)
+	}
+}
+
+func TestPolicyAuthDiff(t *testing.T) {
+	for _, authMethod := range []string{authMethodAPIKey, authMethodBasic} {
+		if err := policyAuthDiff(context.Background(), nil, ProviderMetadata{AuthMethod: authMethod}); err == nil {
+			t.Errorf("expected the plan to fail for the '%s' auth method", authMethod)
+		}
+	}
+	for _, authMethod := range []string{authMethodAccessToken, authMethodOIDC} {
+		if err := policyAuthDiff(context.Background(), nil, ProviderMetadata{AuthMethod: authMethod}); err != nil {
+			t.Errorf("expected no error for the '%s' auth method, got %v", authMethod, err)
+		}
+	}
+}
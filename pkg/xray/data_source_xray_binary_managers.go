@@ -0,0 +1,112 @@
+package xray
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type BinaryManager struct {
+	Id             string `json:"binMgrId"`
+	Url            string `json:"binMgrUrl"`
+	Description    string `json:"binMgrDesc"`
+	LicenseValid   bool   `json:"license_valid"`
+	LicenseExpired bool   `json:"license_expired"`
+	Version        string `json:"version"`
+}
+
+func getBinaryManagers(client *resty.Client) ([]BinaryManager, *resty.Response, error) {
+	var binaryManagers []BinaryManager
+
+	resp, err := client.R().
+		SetResult(&binaryManagers).
+		Get("xray/api/v1/binMgr")
+
+	return binaryManagers, resp, err
+}
+
+func dataSourceXrayBinaryManagers() *schema.Resource {
+	var packBinaryManagers = func(binaryManagers []BinaryManager) []interface{} {
+		var bms []interface{}
+
+		for _, binaryManager := range binaryManagers {
+			bms = append(bms, map[string]interface{}{
+				"id":              binaryManager.Id,
+				"url":             binaryManager.Url,
+				"description":     binaryManager.Description,
+				"license_valid":   binaryManager.LicenseValid,
+				"license_expired": binaryManager.LicenseExpired,
+				"version":         binaryManager.Version,
+			})
+		}
+
+		return bms
+	}
+
+	var dataSourceXrayBinaryManagersRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		binaryManagers, resp, err := getBinaryManagers(m.(ProviderMetadata).Client)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		hash := sha256.Sum256(resp.Body())
+		d.SetId(fmt.Sprintf("%x", hash))
+
+		if err := d.Set("binary_managers", packBinaryManagers(binaryManagers)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceXrayBinaryManagersRead,
+		Description: "Provides the list of binary managers (JFrog Platform Deployments) registered in Xray. Use the `id` attribute for the `bin_mgr_id` attribute of `xray_watch` resources. See [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-GetBinaryManager) for more details.",
+
+		Schema: map[string]*schema.Schema{
+			"binary_managers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the registered binary managers.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the binary manager.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URL of the binary manager.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the binary manager.",
+						},
+						"license_valid": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the binary manager license is valid for Xray.",
+						},
+						"license_expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the binary manager license has expired.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the binary manager.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
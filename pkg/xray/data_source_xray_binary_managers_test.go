@@ -0,0 +1,27 @@
+package xray
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBinaryManagers(t *testing.T) {
+	fqrn := "data.xray_binary_managers.all"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "xray_binary_managers" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(fqrn, "binary_managers.#"),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "binary_managers.*", map[string]string{
+						"id": "default",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,88 @@
+package xray
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const dbSyncStatusId = "xray_db_sync_status"
+
+type DbSyncStatus struct {
+	State                  string `json:"state"`
+	LastSyncTime           string `json:"last_sync_time"`
+	LastSuccessfulSyncTime string `json:"last_successful_sync_time"`
+	FullSync               bool   `json:"full_sync"`
+	Error                  string `json:"error,omitempty"`
+}
+
+func getDbSyncStatus(client *resty.Client) (DbSyncStatus, error) {
+	dbSyncStatus := DbSyncStatus{}
+
+	_, err := client.R().
+		SetResult(&dbSyncStatus).
+		Get("xray/api/v1/dbsync/status")
+
+	return dbSyncStatus, err
+}
+
+func dataSourceXrayDbSyncStatus() *schema.Resource {
+	var dataSourceXrayDbSyncStatusRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		dbSyncStatus, err := getDbSyncStatus(m.(ProviderMetadata).Client)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(dbSyncStatusId)
+
+		setValue := util.MkLens(d)
+
+		setValue("state", dbSyncStatus.State)
+		setValue("last_sync_time", dbSyncStatus.LastSyncTime)
+		setValue("last_successful_sync_time", dbSyncStatus.LastSuccessfulSyncTime)
+		setValue("full_sync", dbSyncStatus.FullSync)
+		errors := setValue("error", dbSyncStatus.Error)
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack DB sync status %q", errors)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceXrayDbSyncStatusRead,
+		Description: "Provides the status of the Xray database sync, which updates the vulnerabilities, licenses and components data. See [Xray DB Sync](https://www.jfrog.com/confluence/display/JFROG/Synchronizing+the+Xray+Database) for more details.",
+
+		Schema: map[string]*schema.Schema{
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the DB sync, e.g. `idle`, `in_progress` or `failed`.",
+			},
+			"last_sync_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last DB sync.",
+			},
+			"last_successful_sync_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last successful DB sync.",
+			},
+			"full_sync": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the last DB sync was a full sync.",
+			},
+			"error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Error of the last DB sync, if it failed.",
+			},
+		},
+	}
+}
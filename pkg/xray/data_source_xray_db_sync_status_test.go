@@ -0,0 +1,26 @@
+package xray
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDbSyncStatus(t *testing.T) {
+	fqrn := "data.xray_db_sync_status.status"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "xray_db_sync_status" "status" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "id", "xray_db_sync_status"),
+					resource.TestCheckResourceAttrSet(fqrn, "state"),
+					resource.TestCheckResourceAttrSet(fqrn, "full_sync"),
+				),
+			},
+		},
+	})
+}
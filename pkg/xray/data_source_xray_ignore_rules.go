@@ -0,0 +1,290 @@
+package xray
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const ignoreRulesPageSize = 100
+
+// The paging stops with an error after this number of pages, in case Xray keeps returning new rules
+const maxIgnoreRulesPages = 1000
+
+// isLastIgnoreRulesPage reports whether all the rules were fetched. Some Xray versions don't return the total count,
+// in this case only the size of the page is checked. Some Xray versions ignore the page number and return the same
+// page again, so the paging also stops when the page has no new rules.
+func isLastIgnoreRulesPage(pageSize, newRules, fetched, totalCount int) bool {
+	return pageSize < ignoreRulesPageSize || newRules == 0 || (totalCount > 0 && fetched >= totalCount)
+}
+
+// Filter attributes of the data source, mapped to the query parameters of the ignore rules list API
+var ignoreRulesQueryParams = map[string]string{
+	"vulnerability":     "vulnerability",
+	"cve":               "cve",
+	"license":           "license",
+	"watch":             "watch",
+	"policy":            "policy",
+	"component_name":    "component_name",
+	"component_version": "component_version",
+	"artifact_name":     "artifact_name",
+	"artifact_version":  "artifact_version",
+	"expires_before":    "expires_before",
+	"expires_after":     "expires_after",
+}
+
+func dataSourceXrayIgnoreRules() *schema.Resource {
+	var nameVersionSchema = func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: description,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"version": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		}
+	}
+
+	var stringSetSchema = func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: description,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+	}
+
+	var filterSchema = func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      description,
+		}
+	}
+
+	var packIgnoreRules = func(ignoreRules []IgnoreRule) []interface{} {
+		var rules []interface{}
+
+		for _, ignoreRule := range ignoreRules {
+			created := ""
+			if ignoreRule.Created != nil {
+				created = ignoreRule.Created.Format(time.RFC3339)
+			}
+			expirationDate := ""
+			if ignoreRule.ExpiresAt != nil {
+				expirationDate = ignoreRule.ExpiresAt.Format("2006-01-02")
+			}
+
+			rules = append(rules, map[string]interface{}{
+				"id":               ignoreRule.Id,
+				"notes":            ignoreRule.Notes,
+				"author":           ignoreRule.Author,
+				"created":          created,
+				"expiration_date":  expirationDate,
+				"is_expired":       ignoreRule.IsExpired,
+				"vulnerabilities":  ignoreRule.IgnoreFilters.Vulnerabilities,
+				"cves":             ignoreRule.IgnoreFilters.CVEs,
+				"licenses":         ignoreRule.IgnoreFilters.Licenese,
+				"operational_risk": ignoreRule.IgnoreFilters.OperationalRisks,
+				"policies":         ignoreRule.IgnoreFilters.Policies,
+				"watches":          ignoreRule.IgnoreFilters.Watches,
+				"docker_layers":    ignoreRule.IgnoreFilters.DockerLayers,
+				"release_bundle":   packFilterNameVersion(ignoreRule.IgnoreFilters.ReleaseBundles),
+				"build":            packFilterNameVersion(ignoreRule.IgnoreFilters.Builds),
+				"component":        packFilterNameVersion(ignoreRule.IgnoreFilters.Components),
+				"artifact":         packFilterNameVersionPath(ignoreRule.IgnoreFilters.Artifacts),
+			})
+		}
+
+		return rules
+	}
+
+	var dataSourceXrayIgnoreRulesRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		queryParams := map[string]string{
+			"order_by":    "created",
+			"sort_order":  "desc",
+			"num_of_rows": strconv.Itoa(ignoreRulesPageSize),
+		}
+		for attribute, queryParam := range ignoreRulesQueryParams {
+			if v, ok := d.GetOk(attribute); ok {
+				queryParams[queryParam] = v.(string)
+			}
+		}
+
+		projectKey := d.Get("project_key").(string)
+		author := d.Get("author").(string)
+
+		var ignoreRules []IgnoreRule
+		fetchedIds := map[string]bool{}
+		for page := 1; ; page++ {
+			if page > maxIgnoreRulesPages {
+				return diag.Errorf("failed to list the ignore rules, more than %d pages were returned", maxIgnoreRulesPages)
+			}
+
+			req, err := getRestyRequest(m.(ProviderMetadata), projectKey)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			queryParams["page_num"] = strconv.Itoa(page)
+			rules, err := getIgnoreRules(req, queryParams)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			// The author filter isn't supported by the API, so it's applied to the returned rules.
+			// The rules deleted by Xray, e.g. the expired ones, are still returned with the 'deleted_at' field.
+			newRules := 0
+			for _, rule := range rules.Data {
+				if fetchedIds[rule.Id] {
+					continue
+				}
+				fetchedIds[rule.Id] = true
+				newRules++
+
+				if len(rule.DeletedAt) > 0 {
+					continue
+				}
+				if len(author) == 0 || strings.EqualFold(rule.Author, author) {
+					ignoreRules = append(ignoreRules, rule)
+				}
+			}
+
+			if isLastIgnoreRulesPage(len(rules.Data), newRules, len(fetchedIds), rules.TotalCount) {
+				break
+			}
+		}
+
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%v", queryParams) + projectKey + author))
+		d.SetId(fmt.Sprintf("%x", hash))
+
+		if err := d.Set("ignore_rules", packIgnoreRules(ignoreRules)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceXrayIgnoreRulesRead,
+		Description: "Provides the list of Xray ignore rules, optionally filtered. For example, the rules expiring soon can be listed with the `expires_before` filter. The rules, which were deleted, e.g. expired, are not listed. " +
+			"See [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-GetIgnoreRules) for more details.",
+
+		Schema: util.MergeMaps(
+			getProjectKeySchema(false, "Only the rules of the project are listed."),
+			map[string]*schema.Schema{
+				"vulnerability":     filterSchema("List only the rules for the vulnerability, e.g. `XRAY-1234`."),
+				"cve":               filterSchema("List only the rules for the CVE, e.g. `CVE-2021-44228`."),
+				"license":           filterSchema("List only the rules for the license, e.g. `MIT`."),
+				"watch":             filterSchema("List only the rules for the watch."),
+				"policy":            filterSchema("List only the rules for the policy."),
+				"component_name":    filterSchema("List only the rules for the component."),
+				"component_version": filterSchema("List only the rules for the component version."),
+				"artifact_name":     filterSchema("List only the rules for the artifact."),
+				"artifact_version":  filterSchema("List only the rules for the artifact version."),
+				"author":            filterSchema("List only the rules created by the user."),
+				"expires_before": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+					Description:      "List only the rules expiring before the time, in RFC3339 format, e.g. `timeadd(timestamp(), \"720h\")`.",
+				},
+				"expires_after": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+					Description:      "List only the rules expiring after the time, in RFC3339 format.",
+				},
+				"ignore_rules": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "List of the ignore rules, the newest first.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "ID of the ignore rule.",
+							},
+							"notes": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Notes of the ignore rule.",
+							},
+							"author": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "User, who created the ignore rule.",
+							},
+							"created": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Creation time of the ignore rule.",
+							},
+							"expiration_date": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Expiration date of the ignore rule, in YYYY-MM-DD format. Empty, if the rule doesn't expire.",
+							},
+							"is_expired": {
+								Type:        schema.TypeBool,
+								Computed:    true,
+								Description: "Whether the ignore rule has expired.",
+							},
+							"vulnerabilities":  stringSetSchema("Ignored vulnerabilities."),
+							"cves":             stringSetSchema("Ignored CVEs."),
+							"licenses":         stringSetSchema("Ignored licenses."),
+							"operational_risk": stringSetSchema("Ignored operational risks."),
+							"policies":         stringSetSchema("Policies, to which the rule applies."),
+							"watches":          stringSetSchema("Watches, to which the rule applies."),
+							"docker_layers":    stringSetSchema("Docker layers, to which the rule applies."),
+							"release_bundle":   nameVersionSchema("Release bundles, to which the rule applies."),
+							"build":            nameVersionSchema("Builds, to which the rule applies."),
+							"component":        nameVersionSchema("Components, to which the rule applies."),
+							"artifact": {
+								Type:        schema.TypeSet,
+								Computed:    true,
+								Description: "Artifacts, to which the rule applies.",
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"name": {
+											Type:     schema.TypeString,
+											Computed: true,
+										},
+										"version": {
+											Type:     schema.TypeString,
+											Computed: true,
+										},
+										"path": {
+											Type:     schema.TypeString,
+											Computed: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+}
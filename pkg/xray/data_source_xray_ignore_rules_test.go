@@ -0,0 +1,81 @@
+package xray
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestAccDataSourceIgnoreRules(t *testing.T) {
+	_, fqrn, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+	dataSourceFqrn := "data.xray_ignore_rules.expiring"
+	expirationDate := time.Now().Add(time.Hour * 48)
+
+	config := util.ExecuteTemplate("TestAccDataSourceIgnoreRules", `
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "{{ .name }}"
+		  expiration_date = "{{ .expirationDate }}"
+		  cves            = ["CVE-2021-44228"]
+
+		  component {
+		    name    = "fake-component"
+		    version = "1.0.0"
+		  }
+		}
+
+		data "xray_ignore_rules" "expiring" {
+		  cve            = "CVE-2021-44228"
+		  expires_before = "{{ .expiresBefore }}"
+
+		  depends_on = [xray_ignore_rule.{{ .name }}]
+		}
+	`, map[string]interface{}{
+		"name":           name,
+		"expirationDate": expirationDate.Format("2006-01-02"),
+		"expiresBefore":  time.Now().Add(time.Hour * 24 * 30).Format(time.RFC3339),
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckIgnoreRule),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceFqrn, "ignore_rules.#"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceFqrn, "ignore_rules.*", map[string]string{
+						"notes":           name,
+						"expiration_date": expirationDate.Format("2006-01-02"),
+						"cves.#":          "1",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestIsLastIgnoreRulesPage(t *testing.T) {
+	cases := []struct {
+		pageSize, newRules, fetched, totalCount int
+		expected                                bool
+	}{
+		{ignoreRulesPageSize, ignoreRulesPageSize, ignoreRulesPageSize, 250, false},
+		{ignoreRulesPageSize, ignoreRulesPageSize, 2 * ignoreRulesPageSize, 200, true},
+		{50, 50, 250, 250, true},
+		// The total count isn't returned
+		{ignoreRulesPageSize, ignoreRulesPageSize, ignoreRulesPageSize, 0, false},
+		{0, 0, ignoreRulesPageSize, 0, true},
+		// The same page is returned again
+		{ignoreRulesPageSize, 0, ignoreRulesPageSize, 0, true},
+	}
+
+	for _, c := range cases {
+		if actual := isLastIgnoreRulesPage(c.pageSize, c.newRules, c.fetched, c.totalCount); actual != c.expected {
+			t.Errorf("isLastIgnoreRulesPage(%d, %d, %d, %d) = %t, expected %t", c.pageSize, c.newRules, c.fetched, c.totalCount, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package xray
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-version"
+	"golang.org/x/exp/slices"
+)
+
+// Upper limit of the concrete filters expanded from a single pattern, so a too broad pattern doesn't create a huge rule
+const maxExpandedIgnoreFilters = 1000
+
+// NoMatchError is returned, when no concrete filters match the version range or the pattern
+type NoMatchError struct {
+	Message string
+}
+
+func (e NoMatchError) Error() string {
+	return e.Message
+}
+
+type ComponentVersions struct {
+	Versions []string `json:"versions"`
+}
+
+type AqlItem struct {
+	Repo string `json:"repo"`
+	Path string `json:"path"`
+	Name string `json:"name"`
+}
+
+type AqlResults struct {
+	Results []AqlItem `json:"results"`
+}
+
+func getComponentVersions(client *resty.Client, name string) ([]string, error) {
+	componentVersions := ComponentVersions{}
+
+	_, err := client.R().
+		SetQueryParam("component_id", name).
+		SetResult(&componentVersions).
+		Get("xray/api/v1/component/versions")
+
+	return componentVersions.Versions, err
+}
+
+type AqlMatch struct {
+	Match string `json:"$match"`
+}
+
+type AqlQuery struct {
+	And []map[string]AqlMatch `json:"$and"`
+}
+
+// Upper limit of the artifacts returned by the AQL search. The results are matched by the pattern afterwards,
+// so the limit is higher than the maximum of the expanded filters.
+const maxAqlSearchResults = 10 * maxExpandedIgnoreFilters
+
+// buildArtifactsAqlQuery returns the AQL query of the artifacts, which may match the Ant-style pattern.
+// AQL doesn't support '**', so the path is matched up to the first '**' segment, and the exact match is left to matchArtifacts.
+func buildArtifactsAqlQuery(pathPattern string) (string, error) {
+	segments := strings.Split(pathPattern, "/")
+	repoPattern, folders, namePattern := segments[0], []string{}, "**"
+	if len(segments) > 1 {
+		folders, namePattern = segments[1:len(segments)-1], segments[len(segments)-1]
+	}
+
+	query := AqlQuery{}
+	if repoPattern == "**" {
+		query.And = append(query.And, map[string]AqlMatch{"repo": {Match: "*"}})
+	} else {
+		query.And = append(query.And, map[string]AqlMatch{"repo": {Match: repoPattern}})
+
+		if namePattern == "**" {
+			folders = append(folders, namePattern)
+		}
+		if index := slices.Index(folders, "**"); index < 0 {
+			// The artifacts in the root of the repository have the '.' path
+			pathMatch := "."
+			if len(folders) > 0 {
+				pathMatch = strings.Join(folders, "/")
+			}
+			query.And = append(query.And, map[string]AqlMatch{"path": {Match: pathMatch}})
+		} else if index > 0 {
+			query.And = append(query.And, map[string]AqlMatch{"path": {Match: strings.Join(folders[:index], "/") + "*"}})
+		}
+	}
+
+	if namePattern != "**" {
+		query.And = append(query.And, map[string]AqlMatch{"name": {Match: namePattern}})
+	}
+
+	criteria, err := json.Marshal(query)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`items.find(%s).include("repo","path","name").limit(%d)`, criteria, maxAqlSearchResults), nil
+}
+
+// searchArtifacts returns the artifacts, which may match the Ant-style pattern
+func searchArtifacts(client *resty.Client, pathPattern string) ([]AqlItem, error) {
+	query, err := buildArtifactsAqlQuery(pathPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	aqlResults := AqlResults{}
+
+	_, err = client.R().
+		SetHeader("Content-Type", "text/plain").
+		SetBody(query).
+		SetResult(&aqlResults).
+		Post("artifactory/api/search/aql")
+	if err != nil {
+		return nil, err
+	}
+	if len(aqlResults.Results) >= maxAqlSearchResults {
+		return nil, fmt.Errorf("more than %d artifacts are found by the pattern '%s'. Narrow the pattern", maxAqlSearchResults, pathPattern)
+	}
+
+	return aqlResults.Results, nil
+}
+
+// matchVersions returns the versions within the range, sorted from the oldest. Versions, which can't be parsed, are skipped.
+func matchVersions(versions []string, versionRange string) ([]string, error) {
+	constraints, err := version.NewConstraint(versionRange)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched version.Collection
+	for _, v := range versions {
+		parsed, err := version.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		if constraints.Check(parsed) {
+			matched = append(matched, parsed)
+		}
+	}
+	sort.Sort(matched)
+
+	var matchedVersions []string
+	for _, v := range matched {
+		matchedVersions = append(matchedVersions, v.Original())
+	}
+
+	return matchedVersions, nil
+}
+
+// matchArtifacts returns the artifacts matching the Ant-style pattern as the ignore rule filters.
+// The path of the filter is the repository and the folder of the artifact, ending with '/'.
+func matchArtifacts(items []AqlItem, pathPattern string) []IgnoreFilterNameVersionPath {
+	var artifacts []IgnoreFilterNameVersionPath
+
+	for _, item := range items {
+		path := item.Repo + "/"
+		if item.Path != "." && len(item.Path) > 0 {
+			path += item.Path + "/"
+		}
+
+		if antPatternMatch(pathPattern, path+item.Name) {
+			artifacts = append(artifacts, IgnoreFilterNameVersionPath{
+				IgnoreFilterNameVersion: IgnoreFilterNameVersion{
+					Name: item.Name,
+				},
+				Path: path,
+			})
+		}
+	}
+
+	return artifacts
+}
+
+// expandComponentRange returns the concrete component filters for the versions of the component within the range
+func expandComponentRange(meta ProviderMetadata, name, versionRange string) ([]IgnoreFilterNameVersion, error) {
+	versions, err := cachedLookup(meta.Cache, "component_versions/"+name, func() ([]string, error) {
+		return getComponentVersions(meta.Client, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matchedVersions, err := matchVersions(versions, versionRange)
+	if err != nil {
+		return nil, err
+	}
+	if len(matchedVersions) == 0 {
+		return nil, NoMatchError{Message: fmt.Sprintf("no versions of component '%s' match the range '%s'", name, versionRange)}
+	}
+	if len(matchedVersions) > maxExpandedIgnoreFilters {
+		return nil, fmt.Errorf("%d versions of component '%s' match the range '%s', the maximum is %d. Narrow the range", len(matchedVersions), name, versionRange, maxExpandedIgnoreFilters)
+	}
+
+	var components []IgnoreFilterNameVersion
+	for _, v := range matchedVersions {
+		components = append(components, IgnoreFilterNameVersion{Name: name, Version: v})
+	}
+
+	return components, nil
+}
+
+// expandArtifactPattern returns the concrete artifact filters for the artifacts matching the Ant-style pattern
+func expandArtifactPattern(client *resty.Client, pathPattern string) ([]IgnoreFilterNameVersionPath, error) {
+	items, err := searchArtifacts(client, pathPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := matchArtifacts(items, pathPattern)
+	if len(artifacts) == 0 {
+		return nil, NoMatchError{Message: fmt.Sprintf("no artifacts match the pattern '%s'", pathPattern)}
+	}
+	if len(artifacts) > maxExpandedIgnoreFilters {
+		return nil, fmt.Errorf("%d artifacts match the pattern '%s', the maximum is %d. Narrow the pattern", len(artifacts), pathPattern, maxExpandedIgnoreFilters)
+	}
+
+	return artifacts, nil
+}
+
+// separateExpandedFilters splits the filters returned by Xray to the configured ones and the ones expanded from the ranges or patterns
+func separateExpandedFilters[T comparable](filters, expanded []T) (configured []T, matched []T) {
+	for _, filter := range filters {
+		if slices.Contains(expanded, filter) {
+			matched = append(matched, filter)
+		} else {
+			configured = append(configured, filter)
+		}
+	}
+
+	return configured, matched
+}
@@ -0,0 +1,171 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// IgnoreRulePolicy holds the provider settings, which are enforced by the xray_ignore_rule resources
+type IgnoreRulePolicy struct {
+	MaxDays           int
+	RequireExpiration bool
+}
+
+// ProviderMetadata is returned by providerConfigure, and passed to the resources and data sources as the meta
+type ProviderMetadata struct {
+	Client *resty.Client
+	// Authentication method used by the client, see getAuthMethod
+	AuthMethod string
+	// Empty, if the version couldn't be detected. The version checks are skipped in this case.
+	XrayVersion string
+	IsSaaS      bool
+	// Capabilities of the Xray instance, see xrayCapabilities
+	Capabilities     map[string]bool
+	IgnoreRulePolicy IgnoreRulePolicy
+	// The entitlements are verified only if the provider 'check_license' is enabled
+	CheckLicense bool
+	// Pointer is used, so the copies of the metadata share the cache
+	Cache *LookupCache
+}
+
+func getIgnoreRulePolicy(m interface{}) IgnoreRulePolicy {
+	if meta, ok := m.(ProviderMetadata); ok {
+		return meta.IgnoreRulePolicy
+	}
+
+	return IgnoreRulePolicy{}
+}
+
+const (
+	capabilityFixVersionDependant    = "fix_version_dependant"
+	capabilityVulnContextualAnalysis = "vuln_contextual_analysis"
+)
+
+type XrayCapability struct {
+	MinVersion string
+	SaaSOnly   bool
+}
+
+var xrayCapabilities = map[string]XrayCapability{
+	// Xray before 3.44.3 ignores 'fix_version_dependant'
+	capabilityFixVersionDependant: {MinVersion: "3.44.3"},
+	// Self-hosted Xray doesn't support 'vuln_contextual_analysis'
+	capabilityVulnContextualAnalysis: {MinVersion: "3.59.0", SaaSOnly: true},
+}
+
+// isSaaSUrl reports whether the URL belongs to a JFrog SaaS instance
+func isSaaSUrl(rawUrl string) bool {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasSuffix(strings.ToLower(parsedUrl.Hostname()), ".jfrog.io")
+}
+
+// getCapabilities returns the capabilities of the Xray instance. If the version is unknown,
+// the version dependent capabilities are assumed to be available.
+func getCapabilities(xrayVersion string, isSaaS bool) map[string]bool {
+	capabilities := map[string]bool{}
+	for name, capability := range xrayCapabilities {
+		capabilities[name] = (isSaaS || !capability.SaaSOnly) &&
+			checkXrayVersion(xrayVersion, capability.MinVersion, name) == nil
+	}
+
+	return capabilities
+}
+
+// checkCapability returns an error explaining why the attribute can't be used, if the Xray instance doesn't have the capability.
+// SaaS isn't always detected, e.g. for a custom domain, so the SaaS only capabilities are only logged as a warning.
+func (m ProviderMetadata) checkCapability(ctx context.Context, name, attribute string) error {
+	if m.Capabilities == nil || m.Capabilities[name] {
+		return nil
+	}
+
+	capability := xrayCapabilities[name]
+	if capability.SaaSOnly && !m.IsSaaS {
+		tflog.Warn(ctx, fmt.Sprintf("%s is only supported by JFrog SaaS instances. Set the provider 'is_saas' attribute to 'true', if the URL points to JFrog SaaS with a custom domain", attribute))
+	}
+
+	return checkXrayVersion(m.XrayVersion, capability.MinVersion, attribute)
+}
+
+// Xray entitlement of JFrog Advanced Security, required by the contextual analysis
+const advancedSecurityFeatureId = "contextual_analysis"
+
+// checkEntitlement returns an error, if the Xray instance isn't entitled to the feature required by the attribute.
+// The check is skipped, if the entitlements can't be requested, e.g. the API isn't supported by the Xray version.
+func (m ProviderMetadata) checkEntitlement(ctx context.Context, featureId, featureName, attribute string) error {
+	if !m.CheckLicense || m.Client == nil {
+		return nil
+	}
+
+	entitled, err := cachedLookup(m.Cache, "entitlement/"+featureId, func() (bool, error) {
+		return getXrayEntitlement(m.Client, featureId)
+	})
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to get the Xray entitlement '%s', the check of %s is skipped: %s", featureId, attribute, err))
+		return nil
+	}
+	if entitled {
+		return nil
+	}
+
+	return fmt.Errorf("%s requires %s, which is not included in the Xray license. Contact JFrog to enable the '%s' entitlement, or unset the attribute", attribute, featureName, featureId)
+}
+
+// LookupCache keeps the results of the lookups shared by the resources, e.g. the list of the binary managers,
+// so they are requested from Xray once per provider instance instead of once per resource.
+type LookupCache struct {
+	mutex   sync.Mutex
+	entries map[string]*lookupCacheEntry
+}
+
+// lookupCacheEntry is locked during the lookup, so the concurrent lookups of the same key wait for a single request,
+// while the lookups of the other keys are not blocked
+type lookupCacheEntry struct {
+	mutex  sync.Mutex
+	value  interface{}
+	cached bool
+}
+
+func NewLookupCache() *LookupCache {
+	return &LookupCache{entries: map[string]*lookupCacheEntry{}}
+}
+
+// cachedLookup returns the cached value for the key, or calls the lookup and caches the result. Errors are not cached.
+func cachedLookup[T any](cache *LookupCache, key string, lookup func() (T, error)) (T, error) {
+	if cache == nil {
+		return lookup()
+	}
+
+	cache.mutex.Lock()
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &lookupCacheEntry{}
+		cache.entries[key] = entry
+	}
+	cache.mutex.Unlock()
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.cached {
+		return entry.value.(T), nil
+	}
+
+	value, err := lookup()
+	if err != nil {
+		return value, err
+	}
+	entry.value = value
+	entry.cached = true
+
+	return value, nil
+}
@@ -0,0 +1,128 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsSaaSUrl(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected bool
+	}{
+		{"https://myorg.jfrog.io", true},
+		{"https://MyOrg.JFrog.io/", true},
+		{"https://myorg.jfrog.io:443/artifactory", true},
+		{"http://localhost:8081", false},
+		{"https://artifactory.example.com", false},
+		{"https://jfrog.io.example.com", false},
+	}
+
+	for _, testCase := range testCases {
+		if actual := isSaaSUrl(testCase.url); actual != testCase.expected {
+			t.Errorf("isSaaSUrl(%q): expected %t, got %t", testCase.url, testCase.expected, actual)
+		}
+	}
+}
+
+func TestCheckCapability(t *testing.T) {
+	testCases := []struct {
+		xrayVersion string
+		isSaaS      bool
+		capability  string
+		expectError bool
+	}{
+		{"", false, capabilityFixVersionDependant, false},
+		{"3.44.3", false, capabilityFixVersionDependant, false},
+		{"3.44.2", true, capabilityFixVersionDependant, true},
+		{"3.60.2", true, capabilityVulnContextualAnalysis, false},
+		// SaaS isn't always detected, so only the warning is logged
+		{"3.60.2", false, capabilityVulnContextualAnalysis, false},
+		{"3.58.0", true, capabilityVulnContextualAnalysis, true},
+		{"", false, capabilityVulnContextualAnalysis, false},
+		{"3.58.0", false, capabilityVulnContextualAnalysis, true},
+	}
+
+	for _, testCase := range testCases {
+		meta := ProviderMetadata{
+			XrayVersion:  testCase.xrayVersion,
+			IsSaaS:       testCase.isSaaS,
+			Capabilities: getCapabilities(testCase.xrayVersion, testCase.isSaaS),
+		}
+		err := meta.checkCapability(context.Background(), testCase.capability, "'attribute'")
+		if (err != nil) != testCase.expectError {
+			t.Errorf("checkCapability(%q) for Xray %q, SaaS %t: expected error %t, got %v", testCase.capability, testCase.xrayVersion, testCase.isSaaS, testCase.expectError, err)
+		}
+	}
+}
+
+func TestCachedLookup(t *testing.T) {
+	cache := NewLookupCache()
+	calls := 0
+	lookup := func() (string, error) {
+		calls++
+		if calls == 1 {
+			return "", fmt.Errorf("lookup failed")
+		}
+		return "value", nil
+	}
+
+	if _, err := cachedLookup(cache, "key", lookup); err == nil {
+		t.Fatal("expected the error of the first lookup")
+	}
+	for i := 0; i < 2; i++ {
+		value, err := cachedLookup(cache, "key", lookup)
+		if err != nil || value != "value" {
+			t.Fatalf("expected cached 'value', got %q, %v", value, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected the failed lookup to be retried once and the result cached, got %d calls", calls)
+	}
+}
+
+func TestCachedLookup_otherKeysNotBlocked(t *testing.T) {
+	cache := NewLookupCache()
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+
+	go cachedLookup(cache, "slow", func() (string, error) {
+		close(started)
+		<-release
+		return "slow", nil
+	})
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cachedLookup(cache, "fast", func() (string, error) { return "fast", nil })
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the lookup of the other key not to wait for the slow lookup")
+	}
+}
+
+func TestCheckEntitlement(t *testing.T) {
+	meta := ProviderMetadata{
+		Client: testXrayClient(t, map[string]testXrayResponse{
+			"/xray/api/v1/entitlements/feature/contextual_analysis": {http.StatusOK, XrayEntitlement{FeatureId: "contextual_analysis", Entitled: false}},
+		}),
+		CheckLicense: true,
+		Cache:        NewLookupCache(),
+	}
+
+	if err := meta.checkEntitlement(context.Background(), "contextual_analysis", "JFrog Advanced Security", "'attribute'"); err == nil {
+		t.Error("expected an error for the feature, which isn't entitled")
+	}
+	// The check is skipped, if the entitlement can't be requested
+	if err := meta.checkEntitlement(context.Background(), "exposures", "JFrog Advanced Security", "'attribute'"); err != nil {
+		t.Errorf("expected the check to be skipped, got %v", err)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,7 +16,7 @@ var commonActionsSchema = map[string]*schema.Schema{
 	"webhooks": {
 		Type:        schema.TypeSet,
 		Optional:    true,
-		Description: "A list of Xray-configured webhook URLs to be invoked if a violation is triggered.",
+		Description: "A list of Xray-configured webhook names to be invoked if a violation is triggered. Webhooks can be managed with the `xray_webhook` resource.",
 		Elem: &schema.Schema{
 			Type: schema.TypeString,
 		},
@@ -79,7 +78,7 @@ var commonActionsSchema = map[string]*schema.Schema{
 		Type:        schema.TypeBool,
 		Optional:    true,
 		Default:     false,
-		Description: "Create Jira Ticket for this Policy Violation. Requires configured Jira integration.",
+		Description: "Create Jira Ticket for this Policy Violation. Requires configured Jira integration, which can be managed with the `xray_jira_integration` resource.",
 	},
 	"build_failure_grace_period_in_days": {
 		Type:             schema.TypeInt,
@@ -659,7 +658,7 @@ func resourceXrayPolicyCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	req, err := getRestyRequest(m.(*resty.Client), policy.ProjectKey)
+	req, err := getRestyRequest(m.(ProviderMetadata), policy.ProjectKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -677,7 +676,7 @@ func resourceXrayPolicyRead(ctx context.Context, d *schema.ResourceData, m inter
 	policy := Policy{}
 
 	projectKey := d.Get("project_key").(string)
-	req, err := getRestyRequest(m.(*resty.Client), projectKey)
+	req, err := getRestyRequest(m.(ProviderMetadata), projectKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -704,7 +703,7 @@ func resourceXrayPolicyUpdate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	req, err := getRestyRequest(m.(*resty.Client), policy.ProjectKey)
+	req, err := getRestyRequest(m.(ProviderMetadata), policy.ProjectKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -723,13 +722,23 @@ func resourceXrayPolicyUpdate(ctx context.Context, d *schema.ResourceData, m int
 	return resourceXrayPolicyRead(ctx, d, m)
 }
 
+// The policy API accepts only the Bearer token, the requests authenticated by the API key or the username and password
+// are rejected during the apply. The auth method isn't known during the validation, so the plan fails instead.
+func policyAuthDiff(_ context.Context, _ *schema.ResourceDiff, m interface{}) error {
+	if meta, ok := m.(ProviderMetadata); ok && (meta.AuthMethod == authMethodAPIKey || meta.AuthMethod == authMethodBasic) {
+		return fmt.Errorf("the Xray policies are only compatible with the Bearer token auth method. Set the provider 'access_token' or 'oidc_provider_name' attribute instead of 'api_key' or 'username' and 'password'")
+	}
+
+	return nil
+}
+
 func resourceXrayPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	policy, err := unpackPolicy(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	req, err := getRestyRequest(m.(*resty.Client), policy.ProjectKey)
+	req, err := getRestyRequest(m.(ProviderMetadata), policy.ProjectKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -3,7 +3,10 @@ package xray
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -35,17 +38,75 @@ func Provider() *schema.Provider {
 				Sensitive:        true,
 				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"XRAY_ACCESS_TOKEN", "JFROG_ACCESS_TOKEN"}, ""),
 				ValidateDiagFunc: validator.StringIsNotEmpty,
-				Description:      "This is a bearer token that can be given to you by your admin under `Identity and Access`",
+				Description:      "This is a bearer token that can be given to you by your admin under `Identity and Access`. This can also be sourced from the `XRAY_ACCESS_TOKEN` or `JFROG_ACCESS_TOKEN` environment variable.",
+			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"XRAY_API_KEY", "JFROG_API_KEY"}, ""),
+				Description: "API key. Used if `access_token` and `oidc_provider_name` are not set. This can also be sourced from the `XRAY_API_KEY` or `JFROG_API_KEY` environment variable.",
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"XRAY_USERNAME", "JFROG_USERNAME"}, ""),
+				RequiredWith: []string{"password"},
+				Description:  "Username for the basic authentication, e.g. to bootstrap a fresh instance. Used if `access_token`, `oidc_provider_name` and `api_key` are not set. This can also be sourced from the `XRAY_USERNAME` or `JFROG_USERNAME` environment variable.",
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"XRAY_PASSWORD", "JFROG_PASSWORD"}, ""),
+				RequiredWith: []string{"username"},
+				Description:  "Password for the basic authentication. This can also be sourced from the `XRAY_PASSWORD` or `JFROG_PASSWORD` environment variable.",
+			},
+			"oidc_provider_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Name of the OIDC integration configured in the JFrog Platform. If set, the ID token issued by the CI is exchanged for a short-lived access token, which is used instead of `access_token`, `api_key`, `username` and `password`.",
+			},
+			"oidc_token_file": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Path to the file with the OIDC ID token. If not set, the token is read from the environment variable `oidc_token_env`.",
+			},
+			"oidc_token_env": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          defaultOIDCTokenEnv,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Name of the environment variable with the OIDC ID token. Default to `JFROG_OIDC_TOKEN`.",
 			},
 			"check_license": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
-				Description: "Toggle for pre-flight checking of Artifactory Pro and Enterprise license. Default to `true`.",
+				Description: "Toggle for pre-flight checking of Artifactory Pro and Enterprise license, Xray availability and Xray license. JFrog Advanced Security entitlement is verified during the plan of the resources using it, e.g. `vuln_contextual_analysis`. Default to `true`.",
+			},
+			"is_saas": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Set to `true`, if the `url` points to JFrog SaaS instance with a custom domain, or to `false` for the self-hosted JFrog Platform. If not set, JFrog SaaS is detected by the `*.jfrog.io` URL. Only the attributes supported by SaaS, e.g. `vuln_contextual_analysis`, depend on it.",
+			},
+			"ignore_rule_max_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validator.IntAtLeast(1),
+				Description:      "Maximum number of days, for which the `xray_ignore_rule` resources can be created. The `expiration_date` and `expires_in_days` attributes are verified during the plan. If not set, the duration is not limited.",
+			},
+			"ignore_rule_require_expiration": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Require the `xray_ignore_rule` resources to have either `expiration_date` or `expires_in_days` set. Default to `false`.",
 			},
 		},
 
-		ResourcesMap: util.AddTelemetry(
+		ResourcesMap: addTelemetry(
 			productId,
 			map[string]*schema.Resource{
 				"xray_security_policy":          resourceXraySecurityPolicyV2(),
@@ -54,14 +115,30 @@ func Provider() *schema.Provider {
 				"xray_watch":                    resourceXrayWatch(),
 				"xray_ignore_rule":              resourceXrayIgnoreRule(),
 				"xray_settings":                 resourceXraySettings(),
+				"xray_basic_settings":           resourceXrayBasicSettings(),
 				"xray_workers_count":            resourceXrayWorkersCount(),
 				"xray_repository_config":        resourceXrayRepositoryConfig(),
+				"xray_repositories_config":      resourceXrayRepositoriesConfig(),
 				"xray_vulnerabilities_report":   resourceXrayVulnerabilitiesReport(),
 				"xray_licenses_report":          resourceXrayLicensesReport(),
 				"xray_violations_report":        resourceXrayViolationsReport(),
 				"xray_operational_risks_report": resourceXrayOperationalRisksReport(),
+				"xray_webhook":                  resourceXrayWebhook(),
+				"xray_jira_integration":         resourceXrayJiraIntegration(),
+				"xray_custom_issue":             resourceXrayCustomIssue(),
+				"xray_custom_license":           resourceXrayCustomLicense(),
+				"xray_indexed_resources":        resourceXrayIndexedResources(),
+				"xray_db_sync":                  resourceXrayDbSync(),
+				"xray_offline_update_source":    resourceXrayOfflineUpdateSource(),
+				"xray_proxy_settings":           resourceXrayProxySettings(),
 			},
 		),
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"xray_binary_managers": dataSourceXrayBinaryManagers(),
+			"xray_db_sync_status":  dataSourceXrayDbSyncStatus(),
+			"xray_ignore_rules":    dataSourceXrayIgnoreRules(),
+		},
 	}
 
 	p.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -77,7 +154,7 @@ func Provider() *schema.Provider {
 	return p
 }
 
-// Creates the client for artifactory, will use token auth
+// Creates the client for artifactory, will use token, API key or basic auth
 func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	URL, ok := d.GetOk("url")
 	if URL == nil || URL == "" || !ok {
@@ -88,12 +165,15 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	accessToken := d.Get("access_token").(string)
-
-	restyBase, err = client.AddAuth(restyBase, "", accessToken)
+	restyBase, err = addAuth(ctx, restyBase, d)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	// The error is already returned by addAuth
+	authMethod, _ := getAuthMethod(d)
+
+	var diags diag.Diagnostics
+	var xrayVersion string
 
 	checkLicense := d.Get("check_license").(bool)
 	if checkLicense {
@@ -101,11 +181,135 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 		if licenseErr != nil {
 			return nil, licenseErr
 		}
+
+		xrayVersion, diags = checkXray(restyBase)
+		if diags.HasError() {
+			return nil, diags
+		}
+	} else {
+		xrayVersion, err = getXrayVersion(restyBase)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Unable to get Xray version, the version checks are skipped: %s", err))
+		}
+	}
+	tflog.Info(ctx, fmt.Sprintf("Xray version: %s", xrayVersion))
+
+	if _, err := version.NewVersion(xrayVersion); xrayVersion != "" && err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to parse Xray version",
+			Detail:   fmt.Sprintf("Xray version %q can't be parsed, the version checks are skipped: %s", xrayVersion, err),
+		})
+		xrayVersion = ""
 	}
 
 	featureUsage := fmt.Sprintf("Terraform/%s", terraformVersion)
 	util.SendUsage(ctx, restyBase, productId, featureUsage)
 
-	return restyBase, nil
+	isSaaS := isSaaSUrl(URL.(string))
+	// The URL doesn't identify JFrog SaaS with a custom domain, so it can be set explicitly
+	if v, ok := d.GetOkExists("is_saas"); ok {
+		isSaaS = v.(bool)
+	}
+
+	return ProviderMetadata{
+		Client:       restyBase,
+		AuthMethod:   authMethod,
+		XrayVersion:  xrayVersion,
+		IsSaaS:       isSaaS,
+		Capabilities: getCapabilities(xrayVersion, isSaaS),
+		Cache:        NewLookupCache(),
+		CheckLicense: checkLicense,
+		IgnoreRulePolicy: IgnoreRulePolicy{
+			MaxDays:           d.Get("ignore_rule_max_days").(int),
+			RequireExpiration: d.Get("ignore_rule_require_expiration").(bool),
+		},
+	}, diags
+
+}
+
+// checkXray verifies Xray is reachable and licensed, and returns its version
+func checkXray(client *resty.Client) (string, diag.Diagnostics) {
+	const skipDetail = "Set the provider 'check_license' attribute to 'false' to skip the pre-flight checks."
+
+	if err := pingXray(client); err != nil {
+		return "", diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Xray is not available",
+			Detail:   fmt.Sprintf("Failed to ping Xray: %s. Verify Xray is installed and running, and the provider 'url' points to the JFrog Platform URL, e.g. https://myorg.jfrog.io. %s", err, skipDetail),
+		}}
+	}
+
+	xrayVersion, err := getXrayVersion(client)
+	if err != nil {
+		return "", diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to get Xray version",
+			Detail:   fmt.Sprintf("Failed to get Xray version: %s. Verify the access token is valid. %s", err, skipDetail),
+		}}
+	}
+
+	binaryManagers, resp, err := getBinaryManagers(client)
+	if err != nil {
+		if resp != nil && (resp.StatusCode() == http.StatusUnauthorized || resp.StatusCode() == http.StatusForbidden) {
+			return xrayVersion, diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Xray license is not verified",
+				Detail:   "Listing the binary managers requires the admin permissions, the Xray license check is skipped.",
+			}}
+		}
+		return "", diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to verify Xray license",
+			Detail:   fmt.Sprintf("Failed to get the Xray binary managers: %s. %s", err, skipDetail),
+		}}
+	}
+
+	var diags diag.Diagnostics
+	for _, binaryManager := range binaryManagers {
+		if binaryManager.LicenseExpired {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Xray license is expired",
+				Detail:   fmt.Sprintf("The Xray license of the binary manager '%s' (%s) is expired. Renew the license in the JFrog Platform. %s", binaryManager.Id, binaryManager.Url, skipDetail),
+			})
+		} else if !binaryManager.LicenseValid {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Xray is not licensed",
+				Detail:   fmt.Sprintf("The Xray license of the binary manager '%s' (%s) is not valid. Verify Artifactory is licensed for Xray. %s", binaryManager.Id, binaryManager.Url, skipDetail),
+			})
+		}
+	}
+
+	return xrayVersion, diags
+}
+
+// addTelemetry sends the usage of the resources, like util.AddTelemetry, which expects the meta to be *resty.Client
+func addTelemetry(productId string, resourceMap map[string]*schema.Resource) map[string]*schema.Resource {
+	var applyTelemetry = func(resource, verb string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			// best effort, the usage is sent in the background
+			featureUsage := fmt.Sprintf("Resource/%s/%s", resource, verb)
+			go util.SendUsage(ctx, m.(ProviderMetadata).Client, productId, featureUsage)
+			return f(ctx, d, m)
+		}
+	}
+
+	for name, skeema := range resourceMap {
+		if skeema.CreateContext != nil {
+			skeema.CreateContext = applyTelemetry(name, "CREATE", skeema.CreateContext)
+		}
+		if skeema.ReadContext != nil {
+			skeema.ReadContext = applyTelemetry(name, "READ", skeema.ReadContext)
+		}
+		if skeema.UpdateContext != nil {
+			skeema.UpdateContext = applyTelemetry(name, "UPDATE", skeema.UpdateContext)
+		}
+		if skeema.DeleteContext != nil {
+			skeema.DeleteContext = applyTelemetry(name, "DELETE", skeema.DeleteContext)
+		}
+	}
 
+	return resourceMap
 }
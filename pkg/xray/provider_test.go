@@ -26,12 +26,108 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestCheckXrayVersion(t *testing.T) {
+	testCases := []struct {
+		xrayVersion string
+		minVersion  string
+		expectError bool
+	}{
+		{"", "3.59.0", false},
+		{"3.59.0", "3.59.0", false},
+		{"3.60.2", "3.59.0", false},
+		{"3.44.2", "3.44.3", true},
+		{"3.8.0", "3.44.3", true},
+		// The check is skipped, if the version can't be parsed
+		{"invalid", "3.44.3", false},
+	}
+
+	for _, testCase := range testCases {
+		err := checkXrayVersion(testCase.xrayVersion, testCase.minVersion, "'feature'")
+		if (err != nil) != testCase.expectError {
+			t.Errorf("checkXrayVersion(%q, %q): expected error %t, got %v", testCase.xrayVersion, testCase.minVersion, testCase.expectError, err)
+		}
+	}
+}
+
+func TestCheckXray(t *testing.T) {
+	ping := testXrayResponse{http.StatusOK, XrayPing{Status: "pong"}}
+	xrayVersion := testXrayResponse{http.StatusOK, XrayVersion{Version: "3.60.2"}}
+
+	testCases := []struct {
+		name            string
+		responses       map[string]testXrayResponse
+		expectedVersion string
+		expectError     bool
+		expectWarning   bool
+	}{
+		{
+			name: "licensed",
+			responses: map[string]testXrayResponse{
+				"/xray/api/v1/system/ping":    ping,
+				"/xray/api/v1/system/version": xrayVersion,
+				"/xray/api/v1/binMgr":         {http.StatusOK, []BinaryManager{{Id: "default", LicenseValid: true}}},
+			},
+			expectedVersion: "3.60.2",
+		},
+		{
+			name: "not reachable",
+			responses: map[string]testXrayResponse{
+				"/xray/api/v1/system/ping": {http.StatusServiceUnavailable, nil},
+			},
+			expectError: true,
+		},
+		{
+			name: "without admin permissions",
+			responses: map[string]testXrayResponse{
+				"/xray/api/v1/system/ping":    ping,
+				"/xray/api/v1/system/version": xrayVersion,
+				"/xray/api/v1/binMgr":         {http.StatusForbidden, nil},
+			},
+			expectedVersion: "3.60.2",
+			expectWarning:   true,
+		},
+		{
+			name: "license expired",
+			responses: map[string]testXrayResponse{
+				"/xray/api/v1/system/ping":    ping,
+				"/xray/api/v1/system/version": xrayVersion,
+				"/xray/api/v1/binMgr":         {http.StatusOK, []BinaryManager{{Id: "default", LicenseValid: true, LicenseExpired: true}}},
+			},
+			expectedVersion: "3.60.2",
+			expectError:     true,
+		},
+		{
+			name: "not licensed",
+			responses: map[string]testXrayResponse{
+				"/xray/api/v1/system/ping":    ping,
+				"/xray/api/v1/system/version": xrayVersion,
+				"/xray/api/v1/binMgr":         {http.StatusOK, []BinaryManager{{Id: "default", LicenseValid: false}}},
+			},
+			expectedVersion: "3.60.2",
+			expectError:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		version, diags := checkXray(testXrayClient(t, testCase.responses))
+		if diags.HasError() != testCase.expectError {
+			t.Errorf("%s: expected error %t, got %v", testCase.name, testCase.expectError, diags)
+		}
+		if hasWarning := !diags.HasError() && len(diags) > 0; hasWarning != testCase.expectWarning {
+			t.Errorf("%s: expected warning %t, got %v", testCase.name, testCase.expectWarning, diags)
+		}
+		if version != testCase.expectedVersion {
+			t.Errorf("%s: expected version %q, got %q", testCase.name, testCase.expectedVersion, version)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	ctx := context.Background()
 	provider, _ := testAccProviders()["xray"]()
-	oldErr := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
-	if oldErr != nil {
-		t.Error(oldErr)
+	diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
+	if diags.HasError() {
+		t.Error(diags)
 	}
 }
 
@@ -81,6 +177,18 @@ func testAccDeleteRepo(t *testing.T, repo string) {
 	}
 }
 
+// Deploy an artifact with the given content to the repository. It will be matched by the patterns in the tests
+func testAccDeployArtifact(t *testing.T, repo, path, content string) {
+	restyClient := GetTestResty(t)
+
+	_, err := restyClient.R().
+		SetBody(content).
+		Put(fmt.Sprintf("artifactory/%s/%s", repo, path))
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func dummyError() *resty.Response {
 	rawResponse := http.Response{
 		StatusCode: http.StatusNotFound,
@@ -0,0 +1,165 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const basicSettingsId = "xray_basic_settings"
+
+type BasicSettings struct {
+	Enabled                       bool `json:"enabled"`
+	AllowDownloadsXrayUnavailable bool `json:"allowDownloadsXrayUnavailable"`
+	AllowBlockedDownload          bool `json:"allowBlockedDownload"`
+	BlockUnscannedTimeoutSeconds  int  `json:"blockUnscannedTimeoutSeconds"`
+	// Pointers are used to keep the server value, if the attribute is not set
+	MaxFileSizeMb          *int `json:"maxFileSizeMb,omitempty"`
+	DefaultRetentionInDays *int `json:"defaultRetentionInDays,omitempty"`
+}
+
+func resourceXrayBasicSettings() *schema.Resource {
+	var basicSettingsSchema = map[string]*schema.Schema{
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Enables the Xray integration with Artifactory. If disabled, Artifactory doesn't send the artifacts to Xray for indexing and doesn't block downloads. Default value is `true`.",
+		},
+		"allow_download_when_unavailable": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Allow downloads of the artifacts, which should be blocked by a policy, when Xray is unavailable. Default value is `false`.",
+		},
+		"allow_blocked_download": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Allow downloads of the artifacts, which are blocked by a policy. The violations are still reported. Default value is `false`.",
+		},
+		"block_unscanned_timeout": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          60,
+			ValidateDiagFunc: validator.IntAtLeast(0),
+			Description:      "Time in seconds Artifactory waits for Xray to finish scanning of a new artifact, before the download is blocked by the `block_download.unscanned` policy action. Default value is `60`.",
+		},
+		"max_file_size": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Computed:         true,
+			ValidateDiagFunc: validator.IntAtLeast(1),
+			Description:      "Maximum size, in MB, of the files scanned by Xray. Larger files are not scanned. If not set, the current value remains unchanged.",
+		},
+		"default_retention_in_days": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Computed:         true,
+			ValidateDiagFunc: validator.IntAtLeast(1),
+			Description:      "Default retention period, in days, of the scan data of the artifacts in the repositories without `xray_repository_config`. If not set, the current value remains unchanged.",
+		},
+	}
+
+	var unpackBasicSettings = func(s *schema.ResourceData) BasicSettings {
+		d := &util.ResourceData{ResourceData: s}
+
+		basicSettings := BasicSettings{
+			Enabled:                       d.GetBool("enabled", false),
+			AllowDownloadsXrayUnavailable: d.GetBool("allow_download_when_unavailable", false),
+			AllowBlockedDownload:          d.GetBool("allow_blocked_download", false),
+			BlockUnscannedTimeoutSeconds:  d.GetInt("block_unscanned_timeout", false),
+		}
+
+		if v, ok := s.GetOk("max_file_size"); ok {
+			maxFileSize := v.(int)
+			basicSettings.MaxFileSizeMb = &maxFileSize
+		}
+		if v, ok := s.GetOk("default_retention_in_days"); ok {
+			defaultRetentionInDays := v.(int)
+			basicSettings.DefaultRetentionInDays = &defaultRetentionInDays
+		}
+
+		return basicSettings
+	}
+
+	var packBasicSettings = func(basicSettings BasicSettings, d *schema.ResourceData) diag.Diagnostics {
+		setValue := util.MkLens(d)
+
+		setValue("enabled", basicSettings.Enabled)
+		setValue("allow_download_when_unavailable", basicSettings.AllowDownloadsXrayUnavailable)
+		setValue("allow_blocked_download", basicSettings.AllowBlockedDownload)
+		errors := setValue("block_unscanned_timeout", basicSettings.BlockUnscannedTimeoutSeconds)
+		if basicSettings.MaxFileSizeMb != nil {
+			errors = setValue("max_file_size", *basicSettings.MaxFileSizeMb)
+		}
+		if basicSettings.DefaultRetentionInDays != nil {
+			errors = setValue("default_retention_in_days", *basicSettings.DefaultRetentionInDays)
+		}
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack basic settings %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceXrayBasicSettingsRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		basicSettings := BasicSettings{}
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetResult(&basicSettings).
+			Get("xray/api/v1/xraySettings")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray basic settings (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return packBasicSettings(basicSettings, d)
+	}
+
+	var resourceXrayBasicSettingsUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		basicSettings := unpackBasicSettings(d)
+
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(basicSettings).
+			Put("xray/api/v1/xraySettings")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(basicSettingsId)
+		return resourceXrayBasicSettingsRead(ctx, d, m)
+	}
+
+	// No delete functionality provided by API for the settings.
+	// Delete function will remove the object from the Terraform state
+	var resourceXrayBasicSettingsDelete = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		tflog.Info(ctx, fmt.Sprintf("Xray basic settings (%s) are removed from the Terraform state, the actual settings remain unchanged", d.Id()))
+		d.SetId("")
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayBasicSettingsUpdate,
+		ReadContext:   resourceXrayBasicSettingsRead,
+		UpdateContext: resourceXrayBasicSettingsUpdate,
+		DeleteContext: resourceXrayBasicSettingsDelete,
+		Description:   "Provides an Xray basic settings resource. Manages the global Xray settings. Only one instance of the resource should be declared.",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: basicSettingsSchema,
+	}
+}
@@ -0,0 +1,82 @@
+package xray
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const basicSettingsTemplate = `
+resource "xray_basic_settings" "{{ .resource_name }}" {
+  enabled                         = true
+  allow_download_when_unavailable = {{ .allow_download_when_unavailable }}
+  allow_blocked_download          = false
+  block_unscanned_timeout         = {{ .block_unscanned_timeout }}
+}
+`
+
+func TestAccBasicSettings(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("basic-settings-", "xray_basic_settings")
+
+	testData := map[string]string{
+		"resource_name":                   resourceName,
+		"allow_download_when_unavailable": "true",
+		"block_unscanned_timeout":         "120",
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["allow_download_when_unavailable"] = "false"
+	updatedTestData["block_unscanned_timeout"] = "60"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, basicSettingsTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "enabled", "true"),
+					resource.TestCheckResourceAttr(fqrn, "allow_download_when_unavailable", testData["allow_download_when_unavailable"]),
+					resource.TestCheckResourceAttr(fqrn, "allow_blocked_download", "false"),
+					resource.TestCheckResourceAttr(fqrn, "block_unscanned_timeout", testData["block_unscanned_timeout"]),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, basicSettingsTemplate, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "allow_download_when_unavailable", updatedTestData["allow_download_when_unavailable"]),
+					resource.TestCheckResourceAttr(fqrn, "block_unscanned_timeout", updatedTestData["block_unscanned_timeout"]),
+				),
+			},
+			{
+				ResourceName:      fqrn,
+				ImportState:       true,
+				ImportStateId:     "xray_basic_settings",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBasicSettings_invalidTimeout(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("basic-settings-", "xray_basic_settings")
+
+	testData := map[string]string{
+		"resource_name":                   resourceName,
+		"allow_download_when_unavailable": "false",
+		"block_unscanned_timeout":         "-1",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, basicSettingsTemplate, testData),
+				ExpectError: regexp.MustCompile(`expected block_unscanned_timeout to be at least \(0\)`),
+			},
+		},
+	})
+}
@@ -0,0 +1,388 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+type CustomIssueComponent struct {
+	Id                 string   `json:"id"`
+	VulnerableVersions []string `json:"vulnerable_versions"`
+	FixedVersions      []string `json:"fixed_versions,omitempty"`
+}
+
+type CustomIssueCve struct {
+	Cve    string `json:"cve,omitempty"`
+	CvssV2 string `json:"cvss_v2,omitempty"`
+	CvssV3 string `json:"cvss_v3,omitempty"`
+}
+
+type CustomIssueSource struct {
+	Id   string `json:"source_id"`
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url,omitempty"`
+}
+
+type CustomIssue struct {
+	Id          string                 `json:"id"`
+	Description string                 `json:"description"`
+	Summary     string                 `json:"summary"`
+	Type        string                 `json:"type"`
+	Provider    string                 `json:"provider"`
+	PackageType string                 `json:"package_type"`
+	Severity    string                 `json:"severity"`
+	Components  []CustomIssueComponent `json:"components"`
+	Cves        []CustomIssueCve       `json:"cves,omitempty"`
+	Sources     []CustomIssueSource    `json:"sources,omitempty"`
+}
+
+// Xray only allows the 'Custom' provider for user defined issues
+const customIssueProvider = "Custom"
+
+func resourceXrayCustomIssue() *schema.Resource {
+	var customIssueSchema = map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.All(
+					validation.StringIsNotEmpty,
+					validation.StringDoesNotMatch(regexp.MustCompile(`(?i)^xray`), "Must not begin with 'xray' (case insensitive)"),
+				),
+			),
+			Description: "ID of the custom issue. It is used as a reference in violations and must not begin with 'xray' (case insensitive).",
+		},
+		"description": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Description of the issue.",
+		},
+		"summary": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Summary of the issue.",
+		},
+		"type": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.StringInSlice(true, "Security", "Versions", "Performance", "Other"),
+			DiffSuppressFunc: ignoreCaseDiffSuppress,
+			Description:      "Type of the issue. Options: `Security`, `Versions`, `Performance`, `Other`. Only `Security` issues trigger violations of `xray_security_policy`.",
+		},
+		"package_type": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Package type of the affected components, e.g. `maven`, `npm`, `docker` or `generic`.",
+		},
+		"severity": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.StringInSlice(true, "Critical", "High", "Medium", "Low", "Information"),
+			DiffSuppressFunc: ignoreCaseDiffSuppress,
+			Description:      "Severity of the issue. Options: `Critical`, `High`, `Medium`, `Low`, `Information`.",
+		},
+		"component": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Description: "Components affected by the issue.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: validator.StringIsNotEmpty,
+						Description:      "ID of the component, e.g. `com.example:artifact` for maven or `lodash` for npm.",
+					},
+					"vulnerable_versions": {
+						Type:        schema.TypeSet,
+						Required:    true,
+						MinItems:    1,
+						Description: "Vulnerable versions of the component. Version ranges use the Maven range syntax, e.g. `[1.0.0]`, `(,1.2.3)` or `[1.0,2.0)`.",
+						Elem: &schema.Schema{
+							Type:             schema.TypeString,
+							ValidateDiagFunc: validator.StringIsNotEmpty,
+						},
+					},
+					"fixed_versions": {
+						Type:        schema.TypeSet,
+						Optional:    true,
+						Description: "Versions of the component that fix the issue.",
+						Elem: &schema.Schema{
+							Type:             schema.TypeString,
+							ValidateDiagFunc: validator.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+		},
+		"cve": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "CVEs related to the issue.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cve": {
+						Type:     schema.TypeString,
+						Optional: true,
+						ValidateDiagFunc: validation.ToDiagFunc(
+							validation.StringMatch(regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`), "Must be in CVE-YYYY-NNNN format"),
+						),
+						Description: "CVE ID, e.g. `CVE-2021-12345`.",
+					},
+					"cvss_v2": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validator.StringIsNotEmpty,
+						Description:      "CVSS v2 score and vector, e.g. `7.5/AV:N/AC:L/Au:N/C:P/I:P/A:P`.",
+					},
+					"cvss_v3": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validator.StringIsNotEmpty,
+						Description:      "CVSS v3 score and vector, e.g. `9.8/CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H`.",
+					},
+				},
+			},
+		},
+		"source": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Sources of the issue, e.g. an internal advisory.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: validator.StringIsNotEmpty,
+						Description:      "ID of the source, e.g. the advisory ID.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Name of the source.",
+					},
+					"url": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+						Description:      "URL of the source.",
+					},
+				},
+			},
+		},
+	}
+
+	var unpackComponents = func(d *schema.ResourceData) []CustomIssueComponent {
+		var components []CustomIssueComponent
+
+		for _, raw := range d.Get("component").(*schema.Set).List() {
+			m := raw.(map[string]interface{})
+			components = append(components, CustomIssueComponent{
+				Id:                 m["id"].(string),
+				VulnerableVersions: util.CastToStringArr(m["vulnerable_versions"].(*schema.Set).List()),
+				FixedVersions:      util.CastToStringArr(m["fixed_versions"].(*schema.Set).List()),
+			})
+		}
+
+		return components
+	}
+
+	var unpackCves = func(d *schema.ResourceData) []CustomIssueCve {
+		var cves []CustomIssueCve
+
+		for _, raw := range d.Get("cve").(*schema.Set).List() {
+			m := raw.(map[string]interface{})
+			cves = append(cves, CustomIssueCve{
+				Cve:    m["cve"].(string),
+				CvssV2: m["cvss_v2"].(string),
+				CvssV3: m["cvss_v3"].(string),
+			})
+		}
+
+		return cves
+	}
+
+	var unpackSources = func(d *schema.ResourceData) []CustomIssueSource {
+		var sources []CustomIssueSource
+
+		for _, raw := range d.Get("source").(*schema.Set).List() {
+			m := raw.(map[string]interface{})
+			sources = append(sources, CustomIssueSource{
+				Id:   m["id"].(string),
+				Name: m["name"].(string),
+				Url:  m["url"].(string),
+			})
+		}
+
+		return sources
+	}
+
+	var unpackCustomIssue = func(s *schema.ResourceData) CustomIssue {
+		d := &util.ResourceData{ResourceData: s}
+
+		return CustomIssue{
+			Id:          d.GetString("name", false),
+			Description: d.GetString("description", false),
+			Summary:     d.GetString("summary", false),
+			Type:        d.GetString("type", false),
+			Provider:    customIssueProvider,
+			PackageType: d.GetString("package_type", false),
+			Severity:    d.GetString("severity", false),
+			Components:  unpackComponents(s),
+			Cves:        unpackCves(s),
+			Sources:     unpackSources(s),
+		}
+	}
+
+	var packComponents = func(components []CustomIssueComponent) []interface{} {
+		var cs []interface{}
+
+		for _, component := range components {
+			cs = append(cs, map[string]interface{}{
+				"id":                  component.Id,
+				"vulnerable_versions": component.VulnerableVersions,
+				"fixed_versions":      component.FixedVersions,
+			})
+		}
+
+		return cs
+	}
+
+	var packCves = func(cves []CustomIssueCve) []interface{} {
+		var cs []interface{}
+
+		for _, cve := range cves {
+			cs = append(cs, map[string]interface{}{
+				"cve":     cve.Cve,
+				"cvss_v2": cve.CvssV2,
+				"cvss_v3": cve.CvssV3,
+			})
+		}
+
+		return cs
+	}
+
+	var packSources = func(sources []CustomIssueSource) []interface{} {
+		var ss []interface{}
+
+		for _, source := range sources {
+			ss = append(ss, map[string]interface{}{
+				"id":   source.Id,
+				"name": source.Name,
+				"url":  source.Url,
+			})
+		}
+
+		return ss
+	}
+
+	var packCustomIssue = func(customIssue CustomIssue, d *schema.ResourceData) diag.Diagnostics {
+		setValue := util.MkLens(d)
+
+		setValue("name", customIssue.Id)
+		setValue("description", customIssue.Description)
+		setValue("summary", customIssue.Summary)
+		setValue("type", customIssue.Type)
+		setValue("package_type", customIssue.PackageType)
+		setValue("severity", customIssue.Severity)
+		setValue("component", packComponents(customIssue.Components))
+		setValue("cve", packCves(customIssue.Cves))
+		errors := setValue("source", packSources(customIssue.Sources))
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack custom issue %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceXrayCustomIssueRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		customIssue := CustomIssue{}
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetResult(&customIssue).
+			SetPathParam("id", d.Id()).
+			Get("xray/api/v2/events/{id}")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray custom issue (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return packCustomIssue(customIssue, d)
+	}
+
+	var resourceXrayCustomIssueCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		customIssue := unpackCustomIssue(d)
+
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(customIssue).
+			Post("xray/api/v1/events")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(customIssue.Id)
+		return resourceXrayCustomIssueRead(ctx, d, m)
+	}
+
+	var resourceXrayCustomIssueUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		customIssue := unpackCustomIssue(d)
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetBody(customIssue).
+			SetPathParam("id", d.Id()).
+			Put("xray/api/v1/events/{id}")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray custom issue (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return resourceXrayCustomIssueRead(ctx, d, m)
+	}
+
+	var resourceXrayCustomIssueDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetPathParam("id", d.Id()).
+			Delete("xray/api/v1/events/{id}")
+		if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayCustomIssueCreate,
+		ReadContext:   resourceXrayCustomIssueRead,
+		UpdateContext: resourceXrayCustomIssueUpdate,
+		DeleteContext: resourceXrayCustomIssueDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema:      customIssueSchema,
+		Description: "Provides an Xray custom issue resource. Custom issues describe vulnerabilities or other issues of in-house components which are not covered by the public vulnerability feeds. Security issues are evaluated by the existing security policies like any other vulnerability. See [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-CreateIssueEvent) for more details.",
+	}
+}
@@ -0,0 +1,115 @@
+package xray
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const customIssueTemplate = `
+	resource "xray_custom_issue" "{{ .resource_name }}" {
+	  name         = "{{ .issue_name }}"
+	  description  = "{{ .description }}"
+	  summary      = "fake summary"
+	  type         = "Security"
+	  package_type = "maven"
+	  severity     = "{{ .severity }}"
+
+	  component {
+	    id                  = "com.example:fake-component"
+	    vulnerable_versions = ["(,1.2.3)"]
+	    fixed_versions      = ["1.2.3"]
+	  }
+
+	  cve {
+	    cve     = "CVE-2022-12345"
+	    cvss_v3 = "9.8/CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"
+	  }
+
+	  source {
+	    id   = "fake-advisory"
+	    name = "fake advisory"
+	    url  = "https://tempurl.org/advisory"
+	  }
+	}
+`
+
+func TestAccCustomIssue_full(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("custom-issue-", "xray_custom_issue")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"issue_name":    fmt.Sprintf("custom-issue-%d", test.RandomInt()),
+		"description":   "custom issue created by xray acceptance tests",
+		"severity":      "High",
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["description"] = "updated custom issue description"
+	updatedTestData["severity"] = "Critical"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckCustomIssue),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, customIssueTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "name", testData["issue_name"]),
+					resource.TestCheckResourceAttr(fqrn, "description", testData["description"]),
+					resource.TestCheckResourceAttr(fqrn, "severity", testData["severity"]),
+					resource.TestCheckResourceAttr(fqrn, "component.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "cve.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "source.#", "1"),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, customIssueTemplate, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "description", updatedTestData["description"]),
+					resource.TestCheckResourceAttr(fqrn, "severity", updatedTestData["severity"]),
+				),
+			},
+			{
+				ResourceName:      fqrn,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCustomIssue_invalidName(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("custom-issue-", "xray_custom_issue")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"issue_name":    fmt.Sprintf("Xray-%d", test.RandomInt()),
+		"description":   "custom issue created by xray acceptance tests",
+		"severity":      "High",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, customIssueTemplate, testData),
+				ExpectError: regexp.MustCompile(`Must not begin with 'xray'`),
+			},
+		},
+	})
+}
+
+func testCheckCustomIssue(id string, request *resty.Request) (*resty.Response, error) {
+	return request.
+		AddRetryCondition(client.NeverRetry).
+		SetPathParam("id", id).
+		Get("xray/api/v2/events/{id}")
+}
@@ -0,0 +1,166 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+type CustomLicense struct {
+	Name     string   `json:"name"`
+	FullName string   `json:"full_name"`
+	Url      string   `json:"url,omitempty"`
+	Category string   `json:"category"`
+	Alias    []string `json:"alias,omitempty"`
+}
+
+func resourceXrayCustomLicense() *schema.Resource {
+	var customLicenseSchema = map[string]*schema.Schema{
+		"name": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Short name of the license, e.g. `ACME-EULA-1.0`. Use this value in `banned_licenses` or `allowed_licenses` of the `xray_license_policy` criteria.",
+		},
+		"full_name": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Full name of the license.",
+		},
+		"url": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+			Description:      "URL of the license text.",
+		},
+		"category": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.StringInSlice(true, "Public Domain", "Permissive", "Weak Copyleft", "Strong Copyleft", "Proprietary", "Unknown"),
+			DiffSuppressFunc: ignoreCaseDiffSuppress,
+			Description:      "Category of the license. Options: `Public Domain`, `Permissive`, `Weak Copyleft`, `Strong Copyleft`, `Proprietary`, `Unknown`.",
+		},
+		"alias": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Regular expressions matching the license names found in the package metadata, which Xray should map to this license, e.g. `(?i)acme.*eula`.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+		},
+	}
+
+	var unpackCustomLicense = func(s *schema.ResourceData) CustomLicense {
+		d := &util.ResourceData{ResourceData: s}
+
+		return CustomLicense{
+			Name:     d.GetString("name", false),
+			FullName: d.GetString("full_name", false),
+			Url:      d.GetString("url", false),
+			Category: d.GetString("category", false),
+			Alias:    d.GetSet("alias"),
+		}
+	}
+
+	var packCustomLicense = func(customLicense CustomLicense, d *schema.ResourceData) diag.Diagnostics {
+		setValue := util.MkLens(d)
+
+		setValue("name", customLicense.Name)
+		setValue("full_name", customLicense.FullName)
+		setValue("url", customLicense.Url)
+		setValue("category", customLicense.Category)
+		errors := setValue("alias", customLicense.Alias)
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack custom license %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceXrayCustomLicenseRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		customLicense := CustomLicense{}
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetResult(&customLicense).
+			SetPathParam("name", d.Id()).
+			Get("xray/api/v1/licenses/{name}")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray custom license (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return packCustomLicense(customLicense, d)
+	}
+
+	var resourceXrayCustomLicenseCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		customLicense := unpackCustomLicense(d)
+
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(customLicense).
+			Post("xray/api/v1/licenses")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(customLicense.Name)
+		return resourceXrayCustomLicenseRead(ctx, d, m)
+	}
+
+	var resourceXrayCustomLicenseUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		customLicense := unpackCustomLicense(d)
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetBody(customLicense).
+			SetPathParam("name", d.Id()).
+			Put("xray/api/v1/licenses/{name}")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray custom license (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return resourceXrayCustomLicenseRead(ctx, d, m)
+	}
+
+	var resourceXrayCustomLicenseDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetPathParam("name", d.Id()).
+			Delete("xray/api/v1/licenses/{name}")
+		if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayCustomLicenseCreate,
+		ReadContext:   resourceXrayCustomLicenseRead,
+		UpdateContext: resourceXrayCustomLicenseUpdate,
+		DeleteContext: resourceXrayCustomLicenseDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema:      customLicenseSchema,
+		Description: "Provides an Xray custom license resource. Custom licenses add proprietary or vendor licenses, which are not part of the Xray license catalogue, so they can be referenced by `xray_license_policy`. See [Xray Licenses](https://www.jfrog.com/confluence/display/JFROG/Xray+Licenses) for more details.",
+	}
+}
@@ -0,0 +1,97 @@
+package xray
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const customLicenseTemplate = `
+	resource "xray_custom_license" "{{ .resource_name }}" {
+	  name      = "{{ .license_name }}"
+	  full_name = "{{ .full_name }}"
+	  url       = "https://tempurl.org/license"
+	  category  = "{{ .category }}"
+	  alias     = ["{{ .alias }}"]
+	}
+`
+
+func TestAccCustomLicense_full(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("custom-license-", "xray_custom_license")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"license_name":  fmt.Sprintf("custom-license-%d", test.RandomInt()),
+		"full_name":     "Custom license created by xray acceptance tests",
+		"category":      "Proprietary",
+		"alias":         "custom-license.*",
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["full_name"] = "Updated custom license"
+	updatedTestData["category"] = "Permissive"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckCustomLicense),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, customLicenseTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "name", testData["license_name"]),
+					resource.TestCheckResourceAttr(fqrn, "full_name", testData["full_name"]),
+					resource.TestCheckResourceAttr(fqrn, "category", testData["category"]),
+					resource.TestCheckTypeSetElemAttr(fqrn, "alias.*", testData["alias"]),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, customLicenseTemplate, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "full_name", updatedTestData["full_name"]),
+					resource.TestCheckResourceAttr(fqrn, "category", updatedTestData["category"]),
+				),
+			},
+			{
+				ResourceName:      fqrn,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCustomLicense_invalidAlias(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("custom-license-", "xray_custom_license")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"license_name":  fmt.Sprintf("custom-license-%d", test.RandomInt()),
+		"full_name":     "Custom license created by xray acceptance tests",
+		"category":      "Proprietary",
+		"alias":         "custom-license(",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, customLicenseTemplate, testData),
+				ExpectError: regexp.MustCompile(`missing closing \)`),
+			},
+		},
+	})
+}
+
+func testCheckCustomLicense(id string, request *resty.Request) (*resty.Response, error) {
+	return request.
+		AddRetryCondition(client.NeverRetry).
+		SetPathParam("name", id).
+		Get("xray/api/v1/licenses/{name}")
+}
@@ -0,0 +1,101 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceXrayDbSync() *schema.Resource {
+	var resourceXrayDbSyncCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client := m.(ProviderMetadata).Client
+
+		_, err := client.R().
+			SetQueryParam("full_db_sync", fmt.Sprintf("%t", d.Get("full_sync").(bool))).
+			Post("xray/api/v1/dbsync")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(resource.PrefixedUniqueId("db-sync-"))
+
+		if !d.Get("wait_for_completion").(bool) {
+			return nil
+		}
+
+		retryErr := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+			dbSyncStatus, err := getDbSyncStatus(client)
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			switch dbSyncStatus.State {
+			case "failed":
+				return resource.NonRetryableError(fmt.Errorf("DB sync failed: %s", dbSyncStatus.Error))
+			case "in_progress":
+				return resource.RetryableError(fmt.Errorf("DB sync is still in progress"))
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("DB sync finished with state '%s'", dbSyncStatus.State))
+			return nil
+		})
+		if retryErr != nil {
+			return diag.FromErr(retryErr)
+		}
+
+		return nil
+	}
+
+	// The sync is a one-time action, so there is nothing to read back from the server
+	var resourceXrayDbSyncRead = func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+		return nil
+	}
+
+	// A running sync can't be cancelled, the resource is only removed from the Terraform state
+	var resourceXrayDbSyncDelete = func(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+		tflog.Info(ctx, fmt.Sprintf("Xray DB sync (%s) is removed from the Terraform state", d.Id()))
+		d.SetId("")
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayDbSyncCreate,
+		ReadContext:   resourceXrayDbSyncRead,
+		DeleteContext: resourceXrayDbSyncDelete,
+		Description: "Provides an Xray DB sync resource. Triggers the sync of the Xray database, when the resource is created or any of the `triggers` changes. " +
+			"Use the `xray_db_sync_status` data source to check the result of the sync.",
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"full_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Triggers a full DB sync, instead of the incremental one. The full sync can take several hours. Default value is `false`.",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Wait for the sync to finish before the resource creation is completed. The waiting time is limited by the `create` timeout. Default value is `false`.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values, which trigger a new DB sync when changed.",
+			},
+		},
+	}
+}
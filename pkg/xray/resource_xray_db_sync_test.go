@@ -0,0 +1,64 @@
+package xray
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const dbSyncTemplate = `
+resource "xray_db_sync" "{{ .resource_name }}" {
+  full_sync = false
+
+  triggers = {
+    version = "{{ .version }}"
+  }
+}
+`
+
+func TestAccDbSync(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("db-sync-", "xray_db_sync")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"version":       "1",
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["version"] = "2"
+
+	var firstId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, dbSyncTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "full_sync", "false"),
+					resource.TestCheckResourceAttr(fqrn, "triggers.version", testData["version"]),
+					func(s *terraform.State) error {
+						firstId = s.RootModule().Resources[fqrn].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, dbSyncTemplate, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "triggers.version", updatedTestData["version"]),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[fqrn].Primary.ID; id == firstId {
+							return fmt.Errorf("expected the DB sync to be triggered again, but ID %s is unchanged", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -2,17 +2,23 @@ package xray
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
 type IgnoreRule struct {
@@ -24,6 +30,19 @@ type IgnoreRule struct {
 	Notes         string        `json:"notes"`
 	ExpiresAt     *time.Time    `json:"expires_at,omitempty"`
 	IgnoreFilters IgnoreFilters `json:"ignore_filters"`
+	// Xray applies soft delete, including the rules deleted when they expire
+	DeletedAt string `json:"deleted_at,omitempty"`
+	DeletedBy string `json:"deleted_by,omitempty"`
+}
+
+type IgnoreRules struct {
+	Data       []IgnoreRule `json:"data"`
+	TotalCount int          `json:"total_count"`
+}
+
+type IgnoreRuleCreateResponse struct {
+	Id   string `json:"id"`
+	Info string `json:"info"`
 }
 
 type IgnoreFilters struct {
@@ -50,6 +69,189 @@ type IgnoreFilterNameVersionPath struct {
 	Path string `json:"path,omitempty"`
 }
 
+var ignoreRuleIdRegex = regexp.MustCompile(`(?i)\bid:?\s*"?([0-9a-z][0-9a-z-]*)"?\s*$`)
+
+// getIgnoreRuleId returns the ID of the created rule. Newer Xray versions return the ID in a separate field,
+// older ones only in the 'info' message, e.g. "Successfully added Ignore rule with id: c0e5b540-1988-42b2-6a86-b444cda1c521"
+func getIgnoreRuleId(response IgnoreRuleCreateResponse) string {
+	if len(response.Id) > 0 {
+		return response.Id
+	}
+
+	matches := ignoreRuleIdRegex.FindStringSubmatch(strings.TrimSpace(response.Info))
+	if len(matches) > 1 {
+		return matches[1]
+	}
+
+	return ""
+}
+
+func getIgnoreRules(req *resty.Request, queryParams map[string]string) (IgnoreRules, error) {
+	ignoreRules := IgnoreRules{}
+
+	_, err := req.
+		SetQueryParams(queryParams).
+		SetResult(&ignoreRules).
+		Get("xray/api/v1/ignore_rules")
+
+	return ignoreRules, err
+}
+
+// normalizeIgnoreFilters sorts the filters, so the filters returned by Xray can be compared with the configured ones
+func normalizeIgnoreFilters(filters IgnoreFilters) IgnoreFilters {
+	sortStrings := func(values []string) []string {
+		sorted := append([]string{}, values...)
+		sort.Strings(sorted)
+		return sorted
+	}
+	sortNameVersions := func(values []IgnoreFilterNameVersion) []IgnoreFilterNameVersion {
+		sorted := append([]IgnoreFilterNameVersion{}, values...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Name+":"+sorted[i].Version < sorted[j].Name+":"+sorted[j].Version
+		})
+		return sorted
+	}
+
+	artifacts := append([]IgnoreFilterNameVersionPath{}, filters.Artifacts...)
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Path+artifacts[i].Name+":"+artifacts[i].Version < artifacts[j].Path+artifacts[j].Name+":"+artifacts[j].Version
+	})
+
+	return IgnoreFilters{
+		Vulnerabilities:  sortStrings(filters.Vulnerabilities),
+		Licenese:         sortStrings(filters.Licenese),
+		CVEs:             sortStrings(filters.CVEs),
+		Policies:         sortStrings(filters.Policies),
+		Watches:          sortStrings(filters.Watches),
+		DockerLayers:     sortStrings(filters.DockerLayers),
+		OperationalRisks: sortStrings(filters.OperationalRisks),
+		ReleaseBundles:   sortNameVersions(filters.ReleaseBundles),
+		Builds:           sortNameVersions(filters.Builds),
+		Components:       sortNameVersions(filters.Components),
+		Artifacts:        artifacts,
+	}
+}
+
+// findIgnoreRule looks up the newest active rule with the same notes and filters. It's used when the ID of the created rule
+// can't be extracted from the response.
+func findIgnoreRule(req *resty.Request, ignoreRule IgnoreRule) (string, error) {
+	ignoreRules, err := getIgnoreRules(req, map[string]string{
+		"order_by":    "created",
+		"sort_order":  "desc",
+		"num_of_rows": "100",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	filters := normalizeIgnoreFilters(ignoreRule.IgnoreFilters)
+	for _, rule := range ignoreRules.Data {
+		if rule.Notes == ignoreRule.Notes && len(rule.DeletedAt) == 0 && reflect.DeepEqual(normalizeIgnoreFilters(rule.IgnoreFilters), filters) {
+			return rule.Id, nil
+		}
+	}
+
+	return "", fmt.Errorf("ignore rule was created, but its ID couldn't be determined from the response or by the lookup of the rules with notes '%s'", ignoreRule.Notes)
+}
+
+var ignoreRuleIssueFilters = []string{"vulnerabilities", "cves", "licenses", "operational_risk"}
+var ignoreRuleScopeFilters = []string{"component", "artifact", "artifact_pattern", "build", "release_bundle", "docker_layers"}
+
+// checkIgnoreRuleFilters verifies the combination of the configured filters. Xray rejects some of the combinations,
+// and the rules without the issue or the scope filter would ignore all future violations.
+func checkIgnoreRuleFilters(isSet func(attribute string) bool) error {
+	var issueFilters []string
+	for _, filter := range ignoreRuleIssueFilters {
+		if isSet(filter) {
+			issueFilters = append(issueFilters, filter)
+		}
+	}
+	if len(issueFilters) == 0 {
+		return fmt.Errorf("one of %s must be set, otherwise the rule ignores all the issues", quoteAttributes(ignoreRuleIssueFilters))
+	}
+	if len(issueFilters) > 1 {
+		return fmt.Errorf("only one of %s can be set, found %s. Use a separate ignore rule for each type of the issue", quoteAttributes(ignoreRuleIssueFilters), quoteAttributes(issueFilters))
+	}
+
+	hasScope := false
+	for _, filter := range ignoreRuleScopeFilters {
+		hasScope = hasScope || isSet(filter)
+	}
+	if !hasScope {
+		return fmt.Errorf("at least one of %s must be set, otherwise the rule ignores the issues in all the resources", quoteAttributes(ignoreRuleScopeFilters))
+	}
+
+	if isSet("policies") && isSet("watches") {
+		return fmt.Errorf("'policies' and 'watches' can't be set together, the rule applies either to the violations of the policies or of the watches")
+	}
+
+	for _, component := range []string{"component", "artifact", "artifact_pattern"} {
+		for _, source := range []string{"build", "release_bundle"} {
+			if isSet(component) && isSet(source) {
+				return fmt.Errorf("'%s' and '%s' can't be set together, the rule applies either to the components and artifacts, or to the builds and release bundles", component, source)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkIgnoreRuleExpiration verifies the expiration date against the provider 'ignore_rule_max_days' and 'ignore_rule_require_expiration' settings
+func checkIgnoreRuleExpiration(policy IgnoreRulePolicy, expirationDate *time.Time, today time.Time) error {
+	if expirationDate == nil {
+		if policy.RequireExpiration || policy.MaxDays > 0 {
+			return fmt.Errorf("'expiration_date' or 'expires_in_days' must be set, as the expiration is required by the provider configuration")
+		}
+		return nil
+	}
+
+	days := int(expirationDate.Sub(today).Hours() / 24)
+	if policy.MaxDays > 0 && days > policy.MaxDays {
+		return fmt.Errorf("ignore rule expires on %s, in %d days, but the maximum allowed by the provider 'ignore_rule_max_days' setting is %d days", expirationDate.Format("2006-01-02"), days, policy.MaxDays)
+	}
+
+	return nil
+}
+
+func quoteAttributes(attributes []string) string {
+	quoted := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		quoted[i] = fmt.Sprintf("'%s'", attribute)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func packFilterNameVersion(filters []IgnoreFilterNameVersion) []interface{} {
+	var fs []interface{}
+
+	for _, filter := range filters {
+		f := map[string]interface{}{
+			"name":    filter.Name,
+			"version": filter.Version,
+		}
+
+		fs = append(fs, f)
+	}
+
+	return fs
+}
+
+func packFilterNameVersionPath(filters []IgnoreFilterNameVersionPath) []interface{} {
+	var fs []interface{}
+
+	for _, filter := range filters {
+		f := map[string]interface{}{
+			"name":    filter.Name,
+			"version": filter.Version,
+			"path":    filter.Path,
+		}
+
+		fs = append(fs, f)
+	}
+
+	return fs
+}
+
 func resourceXrayIgnoreRule() *schema.Resource {
 	var ignoreRuleSchema = util.MergeMaps(
 		getProjectKeySchema(true, ""),
@@ -62,15 +264,22 @@ func resourceXrayIgnoreRule() *schema.Resource {
 			"notes": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
-				Description: "Notes of the ignore rule",
+				Description: "Notes of the ignore rule. Can be updated without the replacement of the rule.",
 			},
 			"expiration_date": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				ForceNew:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"expires_in_days"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^\d{4}-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[0-1])$`), "Data must be in YYYY-MM-DD format")),
-				Description:      "The Ignore Rule will be active until the expiration date. At that date it will automatically get deleted.",
+				Description:      "The Ignore Rule will be active until the expiration date. At that date it will automatically get deleted, and it will be removed from the Terraform state on the next refresh. Can be updated without the replacement of the rule.",
+			},
+			"expires_in_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ConflictsWith:    []string{"expiration_date"},
+				ValidateDiagFunc: validator.IntAtLeast(1),
+				Description:      "Number of days, for which the Ignore Rule will be active. Alternative to `expiration_date`, which is calculated from the number of days during the apply, when the rule is created or the number of days is changed. If both attributes are removed, the rule doesn't expire.",
 			},
 			"author": {
 				Type:     schema.TypeString,
@@ -85,23 +294,21 @@ func resourceXrayIgnoreRule() *schema.Resource {
 				Computed: true,
 			},
 			"vulnerabilities": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				ForceNew:      true,
-				Computed:      true,
-				Description:   "List of specific vulnerabilities to ignore. Omit to apply to all.",
-				ConflictsWith: []string{"cves", "licenses", "operational_risk"},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "List of specific vulnerabilities to ignore. Omit to apply to all.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 			"cves": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				ForceNew:      true,
-				Computed:      true,
-				Description:   "List of specific CVEs to ignore. Omit to apply to all.",
-				ConflictsWith: []string{"vulnerabilities", "licenses", "operational_risk"},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "List of specific CVEs to ignore. Omit to apply to all.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -117,35 +324,32 @@ func resourceXrayIgnoreRule() *schema.Resource {
 				},
 			},
 			"operational_risk": {
-				Type:          schema.TypeList,
-				Optional:      true,
-				ForceNew:      true,
-				Computed:      true,
-				Description:   "Operational risk to ignore. Only accept 'any'",
-				ConflictsWith: []string{"vulnerabilities", "cves", "licenses"},
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "Operational risk to ignore. Only accept 'any'",
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"any"}, true)),
 				},
 			},
 			"policies": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				ForceNew:      true,
-				Computed:      true,
-				Description:   "List of specific policies to ignore. Omit to apply to all.",
-				ConflictsWith: []string{"watches"},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "List of specific policies to ignore. Omit to apply to all.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 			"watches": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				ForceNew:      true,
-				Computed:      true,
-				Description:   "List of specific watches to ignore. Omit to apply to all.",
-				ConflictsWith: []string{"policies"},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "List of specific watches to ignore. Omit to apply to all.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -206,12 +410,11 @@ func resourceXrayIgnoreRule() *schema.Resource {
 				},
 			},
 			"component": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				ForceNew:      true,
-				Computed:      true,
-				ConflictsWith: []string{"build", "release_bundle"},
-				Description:   "List of specific components to ignore. Omit to apply to all.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "List of specific components to ignore. Omit to apply to all.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -225,22 +428,27 @@ func resourceXrayIgnoreRule() *schema.Resource {
 							Description:      "Version of the component",
 							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
 						},
+						"version_range": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "Range of the component versions, e.g. `< 4.17.21` or `>= 1.0, < 2.0`. Can't be used with `version`. The range is expanded to the concrete versions known to Xray, which are tracked in `expanded_components`.",
+							ValidateDiagFunc: versionRange,
+						},
 					},
 				},
 			},
 			"artifact": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				ForceNew:      true,
-				Computed:      true,
-				ConflictsWith: []string{"build", "release_bundle"},
-				Description:   "List of specific artifacts to ignore. Omit to apply to all.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "List of specific artifacts to ignore. Omit to apply to all.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
 							Type:        schema.TypeString,
 							Required:    true,
-							Description: "Name of the artifact. Wildcards are not supported.",
+							Description: "Name of the artifact. Wildcards are not supported, use `artifact_pattern` instead.",
 						},
 						"version": {
 							Type:             schema.TypeString,
@@ -262,38 +470,100 @@ func resourceXrayIgnoreRule() *schema.Resource {
 					},
 				},
 			},
+			"artifact_pattern": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "List of Ant-style patterns of the artifacts to ignore, e.g. `npm-legacy/**` or `libs-release-local/org/example/**/*.jar`. The first segment of the pattern is the repository. The patterns are expanded to the concrete artifacts in Artifactory, which are tracked in `expanded_artifacts`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path_pattern": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: antPattern,
+							Description:      "Ant-style pattern of the artifact path, including the repository.",
+						},
+					},
+				},
+			},
+			"expanded_components": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Components, to which the `version_range` of the `component` blocks was expanded. A new matching version updates the rule on the next apply.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"expanded_artifacts": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Artifacts, to which the `artifact_pattern` blocks were expanded. A new matching artifact updates the rule on the next apply.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	)
 
-	var packFilterNameVersion = func(filters []IgnoreFilterNameVersion) []interface{} {
-		var fs []interface{}
-
-		for _, filter := range filters {
-			f := map[string]interface{}{
-				"name":    filter.Name,
-				"version": filter.Version,
+	var unpackFilterNameVersion = func(attributeName string, d *schema.ResourceData) []IgnoreFilterNameVersion {
+		var filters []IgnoreFilterNameVersion
+		if v, ok := d.GetOkExists(attributeName); ok {
+			for _, f := range v.(*schema.Set).List() {
+				fMap := f.(map[string]interface{})
+				// The version ranges are sent to Xray as the expanded components
+				if versionRange, ok := fMap["version_range"]; ok && len(versionRange.(string)) > 0 {
+					continue
+				}
+				filter := IgnoreFilterNameVersion{
+					Name:    fMap["name"].(string),
+					Version: fMap["version"].(string),
+				}
+				filters = append(filters, filter)
 			}
-
-			fs = append(fs, f)
 		}
 
-		return fs
+		return filters
 	}
 
-	var packFilterNameVersionPath = func(filters []IgnoreFilterNameVersionPath) []interface{} {
-		var fs []interface{}
-
-		for _, filter := range filters {
-			f := map[string]interface{}{
-				"name":    filter.Name,
-				"version": filter.Version,
-				"path":    filter.Path,
+	var unpackFilterNameVersionPath = func(attributeName string, d *schema.ResourceData) []IgnoreFilterNameVersionPath {
+		var filters []IgnoreFilterNameVersionPath
+		if v, ok := d.GetOkExists(attributeName); ok {
+			for _, f := range v.(*schema.Set).List() {
+				fMap := f.(map[string]interface{})
+				filter := IgnoreFilterNameVersionPath{
+					IgnoreFilterNameVersion: IgnoreFilterNameVersion{
+						Name:    fMap["name"].(string),
+						Version: fMap["version"].(string),
+					},
+					Path: fMap["path"].(string),
+				}
+				filters = append(filters, filter)
 			}
-
-			fs = append(fs, f)
 		}
 
-		return fs
+		return filters
 	}
 
 	var packIgnoreRule = func(ignoreRule IgnoreRule, d *schema.ResourceData) diag.Diagnostics {
@@ -306,10 +576,18 @@ func resourceXrayIgnoreRule() *schema.Resource {
 		if err := d.Set("author", ignoreRule.Author); err != nil {
 			return diag.FromErr(err)
 		}
-		if err := d.Set("created", ignoreRule.Created.Format(time.RFC3339)); err != nil {
+		created := ""
+		if ignoreRule.Created != nil {
+			created = ignoreRule.Created.Format(time.RFC3339)
+		}
+		if err := d.Set("created", created); err != nil {
 			return diag.FromErr(err)
 		}
-		if err := d.Set("expiration_date", ignoreRule.ExpiresAt.Format("2006-01-02")); err != nil {
+		expirationDate := ""
+		if ignoreRule.ExpiresAt != nil {
+			expirationDate = ignoreRule.ExpiresAt.Format("2006-01-02")
+		}
+		if err := d.Set("expiration_date", expirationDate); err != nil {
 			return diag.FromErr(err)
 		}
 		if err := d.Set("is_expired", ignoreRule.IsExpired); err != nil {
@@ -333,49 +611,32 @@ func resourceXrayIgnoreRule() *schema.Resource {
 		if err := d.Set("build", packFilterNameVersion(ignoreRule.IgnoreFilters.Builds)); err != nil {
 			return diag.FromErr(err)
 		}
-		if err := d.Set("component", packFilterNameVersion(ignoreRule.IgnoreFilters.Components)); err != nil {
+
+		// The components and artifacts expanded from the ranges and patterns are kept separately,
+		// the ranges themselves are not stored by Xray, so they are kept from the state
+		components, expandedComponents := separateExpandedFilters(ignoreRule.IgnoreFilters.Components, unpackFilterNameVersion("expanded_components", d))
+		packedComponents := packFilterNameVersion(components)
+		for _, component := range d.Get("component").(*schema.Set).List() {
+			if len(component.(map[string]interface{})["version_range"].(string)) > 0 {
+				packedComponents = append(packedComponents, component)
+			}
+		}
+		if err := d.Set("component", packedComponents); err != nil {
 			return diag.FromErr(err)
 		}
-		if err := d.Set("artifact", packFilterNameVersionPath(ignoreRule.IgnoreFilters.Artifacts)); err != nil {
+		if err := d.Set("expanded_components", packFilterNameVersion(expandedComponents)); err != nil {
 			return diag.FromErr(err)
 		}
 
-		return nil
-	}
-
-	var unpackFilterNameVersion = func(attributeName string, d *schema.ResourceData) []IgnoreFilterNameVersion {
-		var filters []IgnoreFilterNameVersion
-		if v, ok := d.GetOkExists(attributeName); ok {
-			for _, f := range v.(*schema.Set).List() {
-				fMap := f.(map[string]interface{})
-				filter := IgnoreFilterNameVersion{
-					Name:    fMap["name"].(string),
-					Version: fMap["version"].(string),
-				}
-				filters = append(filters, filter)
-			}
+		artifacts, expandedArtifacts := separateExpandedFilters(ignoreRule.IgnoreFilters.Artifacts, unpackFilterNameVersionPath("expanded_artifacts", d))
+		if err := d.Set("artifact", packFilterNameVersionPath(artifacts)); err != nil {
+			return diag.FromErr(err)
 		}
-
-		return filters
-	}
-
-	var unpackFilterNameVersionPath = func(attributeName string, d *schema.ResourceData) []IgnoreFilterNameVersionPath {
-		var filters []IgnoreFilterNameVersionPath
-		if v, ok := d.GetOkExists(attributeName); ok {
-			for _, f := range v.(*schema.Set).List() {
-				fMap := f.(map[string]interface{})
-				filter := IgnoreFilterNameVersionPath{
-					IgnoreFilterNameVersion: IgnoreFilterNameVersion{
-						Name:    fMap["name"].(string),
-						Version: fMap["version"].(string),
-					},
-					Path: fMap["path"].(string),
-				}
-				filters = append(filters, filter)
-			}
+		if err := d.Set("expanded_artifacts", packFilterNameVersionPath(expandedArtifacts)); err != nil {
+			return diag.FromErr(err)
 		}
 
-		return filters
+		return nil
 	}
 
 	var unpackIgnnoreRule = func(d *schema.ResourceData) (IgnoreRule, error) {
@@ -421,29 +682,34 @@ func resourceXrayIgnoreRule() *schema.Resource {
 		}
 		ignoreFilters.ReleaseBundles = unpackFilterNameVersion("release_bundle", d)
 		ignoreFilters.Builds = unpackFilterNameVersion("build", d)
-		ignoreFilters.Components = unpackFilterNameVersion("component", d)
-		ignoreFilters.Artifacts = unpackFilterNameVersionPath("artifact", d)
+		ignoreFilters.Components = append(unpackFilterNameVersion("component", d), unpackFilterNameVersion("expanded_components", d)...)
+		ignoreFilters.Artifacts = append(unpackFilterNameVersionPath("artifact", d), unpackFilterNameVersionPath("expanded_artifacts", d)...)
 
 		ignoreRule.IgnoreFilters = ignoreFilters
 
 		return ignoreRule, nil
 	}
 
-	var resourceXrayIgnoreRuleRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var getIgnoreRule = func(id, projectKey string, m interface{}) (IgnoreRule, *resty.Response, error) {
 		ignoreRule := IgnoreRule{}
 
-		projectKey := d.Get("project_key").(string)
-		req, err := getRestyRequest(m.(*resty.Client), projectKey)
+		req, err := getRestyRequest(m.(ProviderMetadata), projectKey)
 		if err != nil {
-			return diag.FromErr(err)
+			return ignoreRule, nil, err
 		}
 
 		resp, err := req.
 			SetResult(&ignoreRule).
 			SetPathParams(map[string]string{
-				"id": d.Id(),
+				"id": id,
 			}).
 			Get("xray/api/v1/ignore_rules/{id}")
+
+		return ignoreRule, resp, err
+	}
+
+	var resourceXrayIgnoreRuleRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ignoreRule, resp, err := getIgnoreRule(d.Id(), d.Get("project_key").(string), m)
 		if err != nil {
 			if resp != nil && resp.StatusCode() == http.StatusNotFound {
 				tflog.Warn(ctx, fmt.Sprintf("Xray ignore rule (%s) not found, removing from state", d.Id()))
@@ -452,22 +718,30 @@ func resourceXrayIgnoreRule() *schema.Resource {
 			return diag.FromErr(err)
 		}
 
-		return packIgnoreRule(ignoreRule, d)
-	}
+		// Xray deletes the rules, when they expire, but still returns them with the 'deleted_at' field
+		if len(ignoreRule.DeletedAt) > 0 {
+			tflog.Warn(ctx, fmt.Sprintf("Xray ignore rule (%s) was deleted by %s at %s, removing from state", d.Id(), ignoreRule.DeletedBy, ignoreRule.DeletedAt))
+			d.SetId("")
 
-	var resourceXrayIgnoreRuleCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		ignoreRule, err := unpackIgnnoreRule(d)
-		if err != nil {
-			return diag.FromErr(err)
-		}
+			detail := fmt.Sprintf("Ignore rule %s was deleted by %s at %s. It will be created again on the next apply.", ignoreRule.Id, ignoreRule.DeletedBy, ignoreRule.DeletedAt)
+			if ignoreRule.IsExpired || (ignoreRule.ExpiresAt != nil && ignoreRule.ExpiresAt.Before(time.Now())) {
+				detail = fmt.Sprintf("Ignore rule %s expired and was deleted by Xray at %s. Update 'expiration_date' to create it again, or remove the resource from the configuration.", ignoreRule.Id, ignoreRule.DeletedAt)
+			}
 
-		req, err := getRestyRequest(m.(*resty.Client), ignoreRule.ProjectKey)
-		if err != nil {
-			return diag.FromErr(err)
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Ignore rule was deleted",
+				Detail:   detail,
+			}}
 		}
 
-		type IgnoreRuleCreateResponse struct {
-			Info string `json:"info"`
+		return packIgnoreRule(ignoreRule, d)
+	}
+
+	var createIgnoreRule = func(ignoreRule IgnoreRule, m interface{}) (string, error) {
+		req, err := getRestyRequest(m.(ProviderMetadata), ignoreRule.ProjectKey)
+		if err != nil {
+			return "", err
 		}
 
 		response := IgnoreRuleCreateResponse{}
@@ -476,41 +750,286 @@ func resourceXrayIgnoreRule() *schema.Resource {
 			SetBody(ignoreRule).
 			SetResult(&response).
 			Post("xray/api/v1/ignore_rules")
+		if err != nil {
+			return "", err
+		}
+
+		if id := getIgnoreRuleId(response); len(id) > 0 {
+			return id, nil
+		}
+
+		req, err = getRestyRequest(m.(ProviderMetadata), ignoreRule.ProjectKey)
+		if err != nil {
+			return "", err
+		}
+
+		return findIgnoreRule(req, ignoreRule)
+	}
+
+	var deleteIgnoreRule = func(id, projectKey string, m interface{}) (*resty.Response, error) {
+		req, err := getRestyRequest(m.(ProviderMetadata), projectKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return req.
+			SetPathParams(map[string]string{
+				"id": id,
+			}).
+			Delete("xray/api/v1/ignore_rules/{id}")
+	}
+
+	// The expiration date is calculated from 'expires_in_days' during the apply, so it doesn't depend on the day of the plan
+	var resolveExpiresInDays = func(d *schema.ResourceData) error {
+		expiresInDays, ok := d.GetOk("expires_in_days")
+		if !ok || (!d.IsNewResource() && !d.HasChange("expires_in_days")) {
+			return nil
+		}
+
+		expirationDate := time.Now().UTC().AddDate(0, 0, expiresInDays.(int))
+		return d.Set("expiration_date", expirationDate.Format("2006-01-02"))
+	}
+
+	var resourceXrayIgnoreRuleCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := resolveExpiresInDays(d); err != nil {
+			return diag.FromErr(err)
+		}
+
+		ignoreRule, err := unpackIgnnoreRule(d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		// response is in this json structure:
-		// {
-		//   info": "Successfully added Ignore rule with id: c0e5b540-1988-42b2-6a86-b444cda1c521"
-		// }
-		// use regex to match the group for the ID
-		re := regexp.MustCompile(`(?m)^Successfully added Ignore rule with id: (.+)$`)
-		matches := re.FindStringSubmatch(response.Info)
-		if len(matches) > 1 {
-			d.SetId(matches[1])
+		id, err := createIgnoreRule(ignoreRule, m)
+		if err != nil {
+			return diag.FromErr(err)
 		}
 
+		d.SetId(id)
 		return resourceXrayIgnoreRuleRead(ctx, d, m)
 	}
 
-	var resourceXrayIgnoreRuleDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only 'notes', 'expiration_date' and the expanded filters can be updated, any other change forces the replacement of the rule.
+	// Xray versions without the update API reject PUT requests, as well as the versions, which can't update the expanded filters.
+	// In this case the replacement rule is created before the old one is deleted, so the violations don't reappear in between.
+	var resourceXrayIgnoreRuleUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := resolveExpiresInDays(d); err != nil {
+			return diag.FromErr(err)
+		}
+
 		ignoreRule, err := unpackIgnnoreRule(d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		req, err := getRestyRequest(m.(*resty.Client), ignoreRule.ProjectKey)
+		req, err := getRestyRequest(m.(ProviderMetadata), ignoreRule.ProjectKey)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
 		resp, err := req.
+			SetBody(ignoreRule).
 			SetPathParams(map[string]string{
 				"id": d.Id(),
 			}).
-			Delete("xray/api/v1/ignore_rules/{id}")
-		if err != nil && resp.StatusCode() == http.StatusInternalServerError {
+			Put("xray/api/v1/ignore_rules/{id}")
+		if err == nil {
+			return resourceXrayIgnoreRuleRead(ctx, d, m)
+		}
+		// Xray versions, which can update only 'notes' and 'expiration_date', reject the changed filters with 400
+		filtersChanged := resp != nil && resp.StatusCode() == http.StatusBadRequest && d.HasChanges("expanded_components", "expanded_artifacts")
+		if !filtersChanged && (resp == nil || (resp.StatusCode() != http.StatusNotFound && resp.StatusCode() != http.StatusMethodNotAllowed)) {
+			return diag.FromErr(err)
+		}
+
+		oldId := d.Id()
+
+		// 404 is returned both by the Xray versions without the update API, and for the rule, which doesn't exist anymore
+		if resp.StatusCode() == http.StatusNotFound {
+			existingRule, _, getErr := getIgnoreRule(oldId, ignoreRule.ProjectKey, m)
+			if getErr != nil || len(existingRule.DeletedAt) > 0 {
+				return diag.FromErr(err)
+			}
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Xray ignore rule (%s) can't be updated, replacing it with a new rule", oldId))
+
+		ignoreRule.Id = ""
+		newId, err := createIgnoreRule(ignoreRule, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(newId)
+
+		var diags diag.Diagnostics
+		resp, err = deleteIgnoreRule(oldId, ignoreRule.ProjectKey, m)
+		if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Failed to delete the replaced ignore rule",
+				Detail:   fmt.Sprintf("Ignore rule %s was replaced by %s, but it couldn't be deleted: %s", oldId, newId, err),
+			})
+		}
+
+		return append(diags, resourceXrayIgnoreRuleRead(ctx, d, m)...)
+	}
+
+	var ignoreRuleExpirationDiff = func(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		config := diff.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+
+		var expirationDate *time.Time
+		if expiresInDays, ok := diff.GetOk("expires_in_days"); ok {
+			// The date is calculated from the number of days, when the rule is created or updated
+			if diff.Id() == "" || diff.HasChange("expires_in_days") {
+				date := today.AddDate(0, 0, expiresInDays.(int))
+				expirationDate = &date
+				if err := diff.SetNewComputed("expiration_date"); err != nil {
+					return err
+				}
+			} else if date, err := time.Parse("2006-01-02", diff.Get("expiration_date").(string)); err == nil {
+				expirationDate = &date
+			}
+		} else if value := config.GetAttr("expiration_date"); value.IsNull() {
+			// The computed date remains in the state, when both 'expiration_date' and 'expires_in_days' are removed
+			if len(diff.Get("expiration_date").(string)) > 0 {
+				if err := diff.SetNew("expiration_date", ""); err != nil {
+					return err
+				}
+			}
+		} else if !value.IsKnown() {
+			// The date is calculated from the other resources, it will be verified during the apply
+			return nil
+		} else {
+			date, err := time.Parse("2006-01-02", value.AsString())
+			if err != nil {
+				return err
+			}
+			expirationDate = &date
+		}
+
+		return checkIgnoreRuleExpiration(getIgnoreRulePolicy(m), expirationDate, today)
+	}
+
+	// The version ranges and path patterns are expanded during the plan, so the concrete filters are shown before they are applied.
+	// A changed expansion, e.g. a new version within the range, updates the rule. If nothing matches an unchanged range or pattern
+	// anymore, e.g. the artifacts were deleted, the existing rule keeps its expansion, as the rule without the expanded filters
+	// would ignore too much. A new range or pattern must match.
+	var ignoreRuleExpansionDiff = func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if !diff.NewValueKnown("component") || !diff.NewValueKnown("artifact_pattern") {
+			if err := diff.SetNewComputed("expanded_components"); err != nil {
+				return err
+			}
+			return diff.SetNewComputed("expanded_artifacts")
+		}
+
+		meta := m.(ProviderMetadata)
+
+		// isUnchanged reports whether the existing rule already has the block, so its expansion can be kept
+		isUnchanged := func(attribute string, block map[string]interface{}) bool {
+			if diff.Id() == "" {
+				return false
+			}
+
+			old, _ := diff.GetChange(attribute)
+			return old.(*schema.Set).Contains(block)
+		}
+
+		oldComponents, _ := diff.GetChange("expanded_components")
+		var expandedComponents []interface{}
+		for _, c := range diff.Get("component").(*schema.Set).List() {
+			component := c.(map[string]interface{})
+			name := component["name"].(string)
+			versionRange := component["version_range"].(string)
+			if len(versionRange) == 0 {
+				continue
+			}
+			if len(component["version"].(string)) > 0 {
+				return fmt.Errorf("component '%s' can't have both 'version' and 'version_range' set", name)
+			}
+
+			components, err := expandComponentRange(meta, name, versionRange)
+			var noMatchError NoMatchError
+			if errors.As(err, &noMatchError) && isUnchanged("component", component) {
+				tflog.Warn(ctx, fmt.Sprintf("Xray ignore rule (%s) keeps the existing 'expanded_components': %s", diff.Id(), err))
+
+				for _, o := range oldComponents.(*schema.Set).List() {
+					oldComponent := o.(map[string]interface{})
+					if oldComponent["name"] != name {
+						continue
+					}
+					if matched, _ := matchVersions([]string{oldComponent["version"].(string)}, versionRange); len(matched) > 0 {
+						expandedComponents = append(expandedComponents, oldComponent)
+					}
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			expandedComponents = append(expandedComponents, packFilterNameVersion(components)...)
+		}
+
+		oldArtifacts, _ := diff.GetChange("expanded_artifacts")
+		var expandedArtifacts []interface{}
+		for _, a := range diff.Get("artifact_pattern").(*schema.Set).List() {
+			artifactPattern := a.(map[string]interface{})
+			pathPattern := artifactPattern["path_pattern"].(string)
+
+			artifacts, err := expandArtifactPattern(meta.Client, pathPattern)
+			var noMatchError NoMatchError
+			if errors.As(err, &noMatchError) && isUnchanged("artifact_pattern", artifactPattern) {
+				tflog.Warn(ctx, fmt.Sprintf("Xray ignore rule (%s) keeps the existing 'expanded_artifacts': %s", diff.Id(), err))
+
+				for _, o := range oldArtifacts.(*schema.Set).List() {
+					oldArtifact := o.(map[string]interface{})
+					if antPatternMatch(pathPattern, oldArtifact["path"].(string)+oldArtifact["name"].(string)) {
+						expandedArtifacts = append(expandedArtifacts, oldArtifact)
+					}
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			expandedArtifacts = append(expandedArtifacts, packFilterNameVersionPath(artifacts)...)
+		}
+
+		if err := diff.SetNew("expanded_components", expandedComponents); err != nil {
+			return err
+		}
+		return diff.SetNew("expanded_artifacts", expandedArtifacts)
+	}
+
+	// Only the configured filters are checked, the computed ones are returned by Xray
+	var ignoreRuleDiff = func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		config := diff.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		return checkIgnoreRuleFilters(func(attribute string) bool {
+			value := config.GetAttr(attribute)
+			if value.IsNull() {
+				return false
+			}
+			// The value, which is not known yet, is set
+			return !value.IsKnown() || value.LengthInt() > 0
+		})
+	}
+
+	var resourceXrayIgnoreRuleDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ignoreRule, err := unpackIgnnoreRule(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		resp, err := deleteIgnoreRule(d.Id(), ignoreRule.ProjectKey, m)
+		if err != nil && resp != nil && resp.StatusCode() == http.StatusInternalServerError {
 			d.SetId("")
 			return diag.FromErr(err)
 		}
@@ -520,13 +1039,22 @@ func resourceXrayIgnoreRule() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceXrayIgnoreRuleCreate,
 		ReadContext:   resourceXrayIgnoreRuleRead,
+		UpdateContext: resourceXrayIgnoreRuleUpdate,
 		DeleteContext: resourceXrayIgnoreRuleDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema:      ignoreRuleSchema,
-		Description: "Provides an Xray ignore rule resource. See [Xray Ignore Rules](https://www.jfrog.com/confluence/display/JFROG/Ignore+Rules) and [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-IGNORERULES) for more details. Notice: at least one of the 'vulnerabilities/cves/liceneses', 'component', and 'docker_layers/artifact/build/release_bundle' should not be empty. When selecting the ignore criteria, take note of the combinations you choose. Some combinations such as omitting everything is not allowed as it will ignore all future violations (in the watch or in the system).",
+		CustomizeDiff: customdiff.All(
+			ignoreRuleDiff,
+			ignoreRuleExpirationDiff,
+			ignoreRuleExpansionDiff,
+		),
+
+		Schema: ignoreRuleSchema,
+		Description: "Provides an Xray ignore rule resource. See [Xray Ignore Rules](https://www.jfrog.com/confluence/display/JFROG/Ignore+Rules) and [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-IGNORERULES) for more details. Notice: exactly one of 'vulnerabilities/cves/licenses/operational_risk', and at least one of 'component/artifact/artifact_pattern/build/release_bundle/docker_layers' must be set. 'policies' and 'watches', as well as 'component/artifact/artifact_pattern' and 'build/release_bundle' are mutually exclusive. The combinations are checked during the plan, as omitting the filters would ignore all future violations (in the watch or in the system). " +
+			"The 'notes' and 'expiration_date' can be updated in place, changes of the other attributes replace the rule. Use the `create_before_destroy` lifecycle option to create the replacement rule before the old one is deleted. " +
+			"Import is not supported for the rules with `version_range` or `artifact_pattern`, as Xray returns only the concrete filters, which can't be told apart from the expanded ones.",
 	}
 }
@@ -3,15 +3,19 @@ package xray
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jfrog/terraform-provider-shared/client"
 	"github.com/jfrog/terraform-provider-shared/test"
 	"github.com/jfrog/terraform-provider-shared/util"
+	"golang.org/x/exp/slices"
 )
 
 func TestAccIgnoreRule_objectives(t *testing.T) {
@@ -31,6 +35,10 @@ func objectiveTestCase(objective string, t *testing.T) (*testing.T, resource.Tes
 		  notes            = "fake notes"
 		  expiration_date  = "{{ .expirationDate }}"
 		  {{ .objective }} = ["fake-{{ .objective }}"]
+
+		  component {
+		    name = "fake-component"
+		  }
 		}
 	`, map[string]interface{}{
 		"name":           name,
@@ -58,6 +66,183 @@ func objectiveTestCase(objective string, t *testing.T) (*testing.T, resource.Tes
 	}
 }
 
+func TestAccIgnoreRule_update(t *testing.T) {
+	_, fqrn, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+	expirationDate := time.Now().Add(time.Hour * 48)
+	updatedExpirationDate := time.Now().Add(time.Hour * 96)
+
+	template := `
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "{{ .notes }}"
+		  expiration_date = "{{ .expirationDate }}"
+		  vulnerabilities = ["any"]
+
+		  component {
+		    name    = "fake-component"
+		    version = "1.0.0"
+		  }
+		}
+	`
+	testData := map[string]interface{}{
+		"name":           name,
+		"notes":          "fake notes",
+		"expirationDate": expirationDate.Format("2006-01-02"),
+	}
+	updatedTestData := map[string]interface{}{
+		"name":           name,
+		"notes":          "updated notes",
+		"expirationDate": updatedExpirationDate.Format("2006-01-02"),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckIgnoreRule),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate("TestAccIgnoreRule", template, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "notes", "fake notes"),
+					resource.TestCheckResourceAttr(fqrn, "expiration_date", expirationDate.Format("2006-01-02")),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate("TestAccIgnoreRule", template, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(fqrn, "id"),
+					resource.TestCheckResourceAttr(fqrn, "notes", "updated notes"),
+					resource.TestCheckResourceAttr(fqrn, "expiration_date", updatedExpirationDate.Format("2006-01-02")),
+					resource.TestCheckResourceAttr(fqrn, "component.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIgnoreRule_without_expiration_date(t *testing.T) {
+	_, fqrn, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+
+	config := util.ExecuteTemplate("TestAccIgnoreRule", `
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "fake notes"
+		  vulnerabilities = ["any"]
+
+		  component {
+		    name    = "fake-component"
+		    version = "1.0.0"
+		  }
+		}
+	`, map[string]interface{}{
+		"name": name,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckIgnoreRule),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(fqrn, "id"),
+					resource.TestCheckResourceAttrSet(fqrn, "created"),
+					resource.TestCheckResourceAttr(fqrn, "expiration_date", ""),
+					resource.TestCheckResourceAttr(fqrn, "is_expired", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIgnoreRule_expires_in_days(t *testing.T) {
+	_, fqrn, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+
+	template := `
+		provider "xray" {
+		  ignore_rule_max_days = 90
+		}
+
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "fake notes"
+		  {{ .expiration }}
+		  vulnerabilities = ["any"]
+
+		  component {
+		    name = "fake-component"
+		  }
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckIgnoreRule),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate("TestAccIgnoreRule", template, map[string]interface{}{
+					"name":       name,
+					"expiration": "expires_in_days = 30",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "expires_in_days", "30"),
+					resource.TestCheckResourceAttr(fqrn, "expiration_date", time.Now().UTC().AddDate(0, 0, 30).Format("2006-01-02")),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate("TestAccIgnoreRule", template, map[string]interface{}{
+					"name":       name,
+					"expiration": "",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "expires_in_days", "0"),
+					resource.TestCheckResourceAttr(fqrn, "expiration_date", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIgnoreRule_exceeds_max_days(t *testing.T) {
+	_, _, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+
+	template := `
+		provider "xray" {
+		  ignore_rule_max_days = 90
+		}
+
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "fake notes"
+		  {{ .expiration }}
+		  vulnerabilities = ["any"]
+
+		  component {
+		    name = "fake-component"
+		  }
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate("TestAccIgnoreRule", template, map[string]interface{}{
+					"name":       name,
+					"expiration": "expires_in_days = 91",
+				}),
+				ExpectError: regexp.MustCompile(`the maximum allowed by the provider 'ignore_rule_max_days' setting is 90 days`),
+			},
+			{
+				Config: util.ExecuteTemplate("TestAccIgnoreRule", template, map[string]interface{}{
+					"name":       name,
+					"expiration": "",
+				}),
+				ExpectError: regexp.MustCompile(`'expiration_date' or 'expires_in_days' must be set`),
+			},
+		},
+	})
+}
+
 func TestAccIgnoreRule_operational_risk(t *testing.T) {
 	_, fqrn, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
 	expirationDate := time.Now().Add(time.Hour * 48)
@@ -128,6 +313,56 @@ func TestAccIgnoreRule_invalid_operational_risk(t *testing.T) {
 	})
 }
 
+func TestAccIgnoreRule_invalid_filters(t *testing.T) {
+	testCases := map[string]struct {
+		filters     string
+		expectError string
+	}{
+		"no issue": {
+			filters:     `component { name = "fake-component" }`,
+			expectError: `one of 'vulnerabilities', 'cves', 'licenses', 'operational_risk' must be set`,
+		},
+		"no scope": {
+			filters:     `cves = ["fake-cve"]`,
+			expectError: `at least one of 'component', 'artifact', 'artifact_pattern', 'build', 'release_bundle', 'docker_layers'`,
+		},
+		"policies and watches": {
+			filters: `cves     = ["fake-cve"]
+			          policies = ["fake-policy"]
+			          watches  = ["fake-watch"]
+			          component { name = "fake-component" }`,
+			expectError: `'policies' and 'watches' can't be set together`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, _, resourceName := test.MkNames("ignore-rule-", "xray_ignore_rule")
+
+			config := util.ExecuteTemplate("TestAccIgnoreRule", `
+				resource "xray_ignore_rule" "{{ .name }}" {
+				  notes = "fake notes"
+				  {{ .filters }}
+				}
+			`, map[string]interface{}{
+				"name":    resourceName,
+				"filters": testCase.filters,
+			})
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders(),
+				Steps: []resource.TestStep{
+					{
+						Config:      config,
+						ExpectError: regexp.MustCompile(testCase.expectError),
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestAccIgnoreRule_scopes(t *testing.T) {
 	for _, scope := range []string{"policies", "watches"} {
 		t.Run(scope, func(t *testing.T) {
@@ -146,6 +381,10 @@ func scopeTestCase(scope string, t *testing.T) (*testing.T, resource.TestCase) {
 		  expiration_date  = "{{ .expirationDate }}"
 		  cves             = ["fake-cve"]
 		  {{ .scope }}     = ["fake-{{ .scope }}"]
+
+		  component {
+		    name = "fake-component"
+		  }
 		}
 	`, map[string]interface{}{
 		"name":           name,
@@ -445,3 +684,435 @@ func testCheckIgnoreRule(id string, request *resty.Request) (*resty.Response, er
 
 	return res, nil
 }
+
+func TestGetIgnoreRuleId(t *testing.T) {
+	testCases := []struct {
+		response IgnoreRuleCreateResponse
+		id       string
+	}{
+		{IgnoreRuleCreateResponse{Info: "Successfully added Ignore rule with id: c0e5b540-1988-42b2-6a86-b444cda1c521"}, "c0e5b540-1988-42b2-6a86-b444cda1c521"},
+		{IgnoreRuleCreateResponse{Info: "Successfully added Ignore rule with id: c0e5b540-1988-42b2-6a86-b444cda1c521\n"}, "c0e5b540-1988-42b2-6a86-b444cda1c521"},
+		{IgnoreRuleCreateResponse{Info: "Ignore rule created, ID c0e5b540-1988-42b2-6a86-b444cda1c521"}, "c0e5b540-1988-42b2-6a86-b444cda1c521"},
+		{IgnoreRuleCreateResponse{Id: "c0e5b540-1988-42b2-6a86-b444cda1c521", Info: "Ignore rule created"}, "c0e5b540-1988-42b2-6a86-b444cda1c521"},
+		{IgnoreRuleCreateResponse{Info: "Ignore rule created"}, ""},
+		{IgnoreRuleCreateResponse{}, ""},
+	}
+
+	for _, testCase := range testCases {
+		if id := getIgnoreRuleId(testCase.response); id != testCase.id {
+			t.Errorf("expected ID '%s' for response %+v, got '%s'", testCase.id, testCase.response, id)
+		}
+	}
+}
+
+func TestNormalizeIgnoreFilters(t *testing.T) {
+	configured := IgnoreFilters{
+		Vulnerabilities: []string{"XRAY-2", "XRAY-1"},
+		Components: []IgnoreFilterNameVersion{
+			{Name: "b", Version: "1.0.0"},
+			{Name: "a", Version: "2.0.0"},
+		},
+	}
+	returned := IgnoreFilters{
+		Vulnerabilities: []string{"XRAY-1", "XRAY-2"},
+		Components: []IgnoreFilterNameVersion{
+			{Name: "a", Version: "2.0.0"},
+			{Name: "b", Version: "1.0.0"},
+		},
+	}
+
+	if !reflect.DeepEqual(normalizeIgnoreFilters(configured), normalizeIgnoreFilters(returned)) {
+		t.Errorf("expected the filters to be equal after normalization: %+v, %+v", configured, returned)
+	}
+	if configured.Vulnerabilities[0] != "XRAY-2" {
+		t.Errorf("expected the original filters to remain unchanged, got %+v", configured)
+	}
+
+	returned.Components[0].Version = "3.0.0"
+	if reflect.DeepEqual(normalizeIgnoreFilters(configured), normalizeIgnoreFilters(returned)) {
+		t.Errorf("expected the filters to differ: %+v, %+v", configured, returned)
+	}
+}
+
+func TestCheckIgnoreRuleFilters(t *testing.T) {
+	testCases := []struct {
+		filters     []string
+		expectError string
+	}{
+		{[]string{"vulnerabilities", "component"}, ""},
+		{[]string{"cves", "docker_layers", "watches"}, ""},
+		{[]string{"licenses", "build", "release_bundle", "policies"}, ""},
+		{[]string{"operational_risk", "component", "artifact"}, ""},
+		{[]string{"component"}, "one of 'vulnerabilities', 'cves', 'licenses', 'operational_risk' must be set"},
+		{[]string{"vulnerabilities", "cves", "component"}, "only one of 'vulnerabilities', 'cves', 'licenses', 'operational_risk' can be set, found 'vulnerabilities', 'cves'"},
+		{[]string{"vulnerabilities", "policies"}, "at least one of 'component', 'artifact', 'artifact_pattern', 'build', 'release_bundle', 'docker_layers' must be set"},
+		{[]string{"vulnerabilities", "component", "policies", "watches"}, "'policies' and 'watches' can't be set together"},
+		{[]string{"vulnerabilities", "artifact", "release_bundle"}, "'artifact' and 'release_bundle' can't be set together"},
+	}
+
+	for _, testCase := range testCases {
+		err := checkIgnoreRuleFilters(func(attribute string) bool {
+			return slices.Contains(testCase.filters, attribute)
+		})
+
+		if len(testCase.expectError) == 0 && err != nil {
+			t.Errorf("expected filters %q to be valid, got error: %s", testCase.filters, err)
+		}
+		if len(testCase.expectError) > 0 && (err == nil || !strings.Contains(err.Error(), testCase.expectError)) {
+			t.Errorf("expected filters %q to fail with '%s', got: %v", testCase.filters, testCase.expectError, err)
+		}
+	}
+}
+
+func TestCheckIgnoreRuleExpiration(t *testing.T) {
+	today := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	in := func(days int) *time.Time {
+		date := today.AddDate(0, 0, days)
+		return &date
+	}
+
+	testCases := []struct {
+		policy         IgnoreRulePolicy
+		expirationDate *time.Time
+		expectError    string
+	}{
+		{IgnoreRulePolicy{}, nil, ""},
+		{IgnoreRulePolicy{}, in(365), ""},
+		{IgnoreRulePolicy{RequireExpiration: true}, in(365), ""},
+		{IgnoreRulePolicy{RequireExpiration: true}, nil, "'expiration_date' or 'expires_in_days' must be set"},
+		{IgnoreRulePolicy{MaxDays: 90}, nil, "'expiration_date' or 'expires_in_days' must be set"},
+		{IgnoreRulePolicy{MaxDays: 90}, in(90), ""},
+		{IgnoreRulePolicy{MaxDays: 90}, in(91), "ignore rule expires on 2023-03-02, in 91 days, but the maximum allowed by the provider 'ignore_rule_max_days' setting is 90 days"},
+	}
+
+	for _, testCase := range testCases {
+		err := checkIgnoreRuleExpiration(testCase.policy, testCase.expirationDate, today)
+
+		if len(testCase.expectError) == 0 && err != nil {
+			t.Errorf("expected expiration %v to be valid for %+v, got error: %s", testCase.expirationDate, testCase.policy, err)
+		}
+		if len(testCase.expectError) > 0 && (err == nil || !strings.Contains(err.Error(), testCase.expectError)) {
+			t.Errorf("expected expiration %v to fail for %+v with '%s', got: %v", testCase.expirationDate, testCase.policy, testCase.expectError, err)
+		}
+	}
+}
+
+func TestAccIgnoreRule_component_version_range(t *testing.T) {
+	_, fqrn, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+	expirationDate := time.Now().Add(time.Hour * 48)
+
+	config := util.ExecuteTemplate("TestAccIgnoreRule", `
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "fake notes"
+		  expiration_date = "{{ .expirationDate }}"
+		  vulnerabilities = ["any"]
+
+		  component {
+		    name          = "npm://lodash"
+		    version_range = ">= 4.17.19, < 4.17.21"
+		  }
+		}
+	`, map[string]interface{}{
+		"name":           name,
+		"expirationDate": expirationDate.Format("2006-01-02"),
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckIgnoreRule),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "component.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "component.0.version_range", ">= 4.17.19, < 4.17.21"),
+					resource.TestCheckResourceAttr(fqrn, "expanded_components.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "expanded_components.*", map[string]string{
+						"name":    "npm://lodash",
+						"version": "4.17.20",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIgnoreRule_invalid_version_range(t *testing.T) {
+	_, _, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+
+	config := util.ExecuteTemplate("TestAccIgnoreRule", `
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "fake notes"
+		  vulnerabilities = ["any"]
+
+		  component {
+		    name          = "npm://lodash"
+		    version_range = "before 4.17.21"
+		  }
+		}
+	`, map[string]interface{}{
+		"name": name,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`to be a version range`),
+			},
+		},
+	})
+}
+
+func TestAccIgnoreRule_artifact_pattern(t *testing.T) {
+	_, fqrn, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+	repo := fmt.Sprintf("ignore-rule-local-%d", test.RandomInt())
+	expirationDate := time.Now().Add(time.Hour * 48)
+
+	config := util.ExecuteTemplate("TestAccIgnoreRule", `
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "fake notes"
+		  expiration_date = "{{ .expirationDate }}"
+		  vulnerabilities = ["any"]
+
+		  artifact_pattern {
+		    path_pattern = "{{ .repo }}/libs/**/*.jar"
+		  }
+		}
+	`, map[string]interface{}{
+		"name":           name,
+		"repo":           repo,
+		"expirationDate": expirationDate.Format("2006-01-02"),
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCreateRepos(t, repo, "local", "")
+			testAccDeployArtifact(t, repo, "libs/a/fake-1.0.0.jar", "fake")
+			testAccDeployArtifact(t, repo, "libs/fake-1.0.0.pom", "fake")
+			testAccDeployArtifact(t, repo, "other/fake-1.0.0.jar", "fake")
+		},
+		ProviderFactories: testAccProviders(),
+		CheckDestroy: func(s *terraform.State) error {
+			testAccDeleteRepo(t, repo)
+			return verifyDeleted(fqrn, testCheckIgnoreRule)(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "artifact_pattern.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "artifact.#", "0"),
+					resource.TestCheckResourceAttr(fqrn, "expanded_artifacts.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "expanded_artifacts.*", map[string]string{
+						"name": "fake-1.0.0.jar",
+						"path": repo + "/libs/a/",
+					}),
+				),
+			},
+			{
+				// A new matching artifact changes the expansion, which updates the rule
+				PreConfig: func() {
+					testAccDeployArtifact(t, repo, "libs/b/c/fake-2.0.0.jar", "fake")
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "expanded_artifacts.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "expanded_artifacts.*", map[string]string{
+						"name": "fake-2.0.0.jar",
+						"path": repo + "/libs/b/c/",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIgnoreRule_component_version_range_expansion_changed(t *testing.T) {
+	_, fqrn, name := test.MkNames("ignore-rule-", "xray_ignore_rule")
+	expirationDate := time.Now().Add(time.Hour * 48)
+
+	template := `
+		resource "xray_ignore_rule" "{{ .name }}" {
+		  notes           = "fake notes"
+		  expiration_date = "{{ .expirationDate }}"
+		  vulnerabilities = ["any"]
+
+		  component {
+		    name          = "npm://lodash"
+		    version_range = "{{ .versionRange }}"
+		  }
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckIgnoreRule),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate("TestAccIgnoreRule", template, map[string]interface{}{
+					"name":           name,
+					"expirationDate": expirationDate.Format("2006-01-02"),
+					"versionRange":   ">= 4.17.19, < 4.17.21",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "expanded_components.#", "2"),
+				),
+			},
+			{
+				// The range is updated in place, the changed expansion is sent as the filters.
+				// If Xray doesn't support the update of the filters, the rule is replaced.
+				Config: util.ExecuteTemplate("TestAccIgnoreRule", template, map[string]interface{}{
+					"name":           name,
+					"expirationDate": expirationDate.Format("2006-01-02"),
+					"versionRange":   ">= 4.17.19, <= 4.17.21",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "component.0.version_range", ">= 4.17.19, <= 4.17.21"),
+					resource.TestCheckResourceAttr(fqrn, "expanded_components.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "expanded_components.*", map[string]string{
+						"name":    "npm://lodash",
+						"version": "4.17.21",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestBuildArtifactsAqlQuery(t *testing.T) {
+	limit := fmt.Sprintf(`.include("repo","path","name").limit(%d)`, maxAqlSearchResults)
+
+	testCases := []struct {
+		pattern  string
+		expected string
+	}{
+		{
+			pattern:  "npm-legacy/**",
+			expected: `items.find({"$and":[{"repo":{"$match":"npm-legacy"}}]})`,
+		},
+		{
+			pattern:  "npm-legacy/lodash/**",
+			expected: `items.find({"$and":[{"repo":{"$match":"npm-legacy"}},{"path":{"$match":"lodash*"}}]})`,
+		},
+		{
+			pattern:  "npm-*/**/lodash-*.tgz",
+			expected: `items.find({"$and":[{"repo":{"$match":"npm-*"}},{"name":{"$match":"lodash-*.tgz"}}]})`,
+		},
+		{
+			pattern:  "libs-release-local/org/example/**/*.jar",
+			expected: `items.find({"$and":[{"repo":{"$match":"libs-release-local"}},{"path":{"$match":"org/example*"}},{"name":{"$match":"*.jar"}}]})`,
+		},
+		{
+			pattern:  "npm-legacy/*.json",
+			expected: `items.find({"$and":[{"repo":{"$match":"npm-legacy"}},{"path":{"$match":"."}},{"name":{"$match":"*.json"}}]})`,
+		},
+		{
+			pattern:  "**/*.jar",
+			expected: `items.find({"$and":[{"repo":{"$match":"*"}},{"name":{"$match":"*.jar"}}]})`,
+		},
+		{
+			// The quotes are escaped, so they can't change the query
+			pattern:  `repo/"}}).delete(/*.jar`,
+			expected: `items.find({"$and":[{"repo":{"$match":"repo"}},{"path":{"$match":"\"}}).delete("}},{"name":{"$match":"*.jar"}}]})`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		query, err := buildArtifactsAqlQuery(testCase.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if query != testCase.expected+limit {
+			t.Errorf("expected query %s for pattern '%s', got %s", testCase.expected+limit, testCase.pattern, query)
+		}
+	}
+}
+
+func TestMatchVersions(t *testing.T) {
+	versions := []string{"4.17.21", "4.17.4", "4.17.20", "3.10.1", "not-a-version", "4.17.15"}
+
+	matched, err := matchVersions(versions, "< 4.17.21, >= 4.17.4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"4.17.4", "4.17.15", "4.17.20"}
+	if !reflect.DeepEqual(matched, expected) {
+		t.Errorf("expected versions %q, got %q", expected, matched)
+	}
+
+	if _, err := matchVersions(versions, "before 4.17.21"); err == nil {
+		t.Errorf("expected invalid range to fail")
+	}
+}
+
+func TestMatchArtifacts(t *testing.T) {
+	items := []AqlItem{
+		{Repo: "npm-legacy", Path: "lodash/-", Name: "lodash-4.17.20.tgz"},
+		{Repo: "npm-legacy", Path: ".", Name: "index.json"},
+		{Repo: "npm-legacy", Path: "express/-", Name: "express-4.18.2.tgz"},
+		{Repo: "npm-remote", Path: "lodash/-", Name: "lodash-4.17.21.tgz"},
+	}
+
+	testCases := []struct {
+		pattern  string
+		expected []IgnoreFilterNameVersionPath
+	}{
+		{
+			pattern: "npm-legacy/lodash/**",
+			expected: []IgnoreFilterNameVersionPath{
+				{IgnoreFilterNameVersion: IgnoreFilterNameVersion{Name: "lodash-4.17.20.tgz"}, Path: "npm-legacy/lodash/-/"},
+			},
+		},
+		{
+			pattern: "npm-*/**/lodash-*.tgz",
+			expected: []IgnoreFilterNameVersionPath{
+				{IgnoreFilterNameVersion: IgnoreFilterNameVersion{Name: "lodash-4.17.20.tgz"}, Path: "npm-legacy/lodash/-/"},
+				{IgnoreFilterNameVersion: IgnoreFilterNameVersion{Name: "lodash-4.17.21.tgz"}, Path: "npm-remote/lodash/-/"},
+			},
+		},
+		{
+			pattern: "npm-legacy/*.json",
+			expected: []IgnoreFilterNameVersionPath{
+				{IgnoreFilterNameVersion: IgnoreFilterNameVersion{Name: "index.json"}, Path: "npm-legacy/"},
+			},
+		},
+		{
+			pattern:  "maven-local/**",
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		if artifacts := matchArtifacts(items, testCase.pattern); !reflect.DeepEqual(artifacts, testCase.expected) {
+			t.Errorf("expected artifacts %+v for pattern '%s', got %+v", testCase.expected, testCase.pattern, artifacts)
+		}
+	}
+}
+
+func TestSeparateExpandedFilters(t *testing.T) {
+	components := []IgnoreFilterNameVersion{
+		{Name: "npm://lodash", Version: "4.17.20"},
+		{Name: "npm://express", Version: "4.18.2"},
+		{Name: "npm://lodash", Version: "4.17.19"},
+	}
+	expanded := []IgnoreFilterNameVersion{
+		{Name: "npm://lodash", Version: "4.17.19"},
+		{Name: "npm://lodash", Version: "4.17.20"},
+	}
+
+	configured, matched := separateExpandedFilters(components, expanded)
+
+	if !reflect.DeepEqual(configured, []IgnoreFilterNameVersion{{Name: "npm://express", Version: "4.18.2"}}) {
+		t.Errorf("unexpected configured components %+v", configured)
+	}
+	if len(matched) != 2 {
+		t.Errorf("expected 2 expanded components, got %+v", matched)
+	}
+}
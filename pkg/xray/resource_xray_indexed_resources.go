@@ -0,0 +1,476 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+	"golang.org/x/exp/slices"
+)
+
+type IndexedRepo struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	PackageType string `json:"pkg_type"`
+}
+
+type BinMgrRepos struct {
+	BinMgrId        string        `json:"bin_mgr_id"`
+	IndexedRepos    []IndexedRepo `json:"indexed_repos"`
+	NonIndexedRepos []IndexedRepo `json:"non_indexed_repos"`
+}
+
+type BuildsIndexingFilters struct {
+	IncludePatterns []string `json:"include_patterns"`
+	ExcludePatterns []string `json:"exclude_patterns"`
+}
+
+type BinMgrBuilds struct {
+	BinMgrId         string                 `json:"bin_mgr_id"`
+	IndexedBuilds    []string               `json:"indexed_builds"`
+	NonIndexedBuilds []string               `json:"non_indexed_builds"`
+	IndexingFilters  *BuildsIndexingFilters `json:"indexing_filters,omitempty"`
+}
+
+type BinMgrReleaseBundles struct {
+	BinMgrId                 string   `json:"bin_mgr_id"`
+	IndexedReleaseBundles    []string `json:"indexed_release_bundles"`
+	NonIndexedReleaseBundles []string `json:"non_indexed_release_bundles"`
+}
+
+func getBinMgrRepos(client *resty.Client, binMgrId string) (BinMgrRepos, error) {
+	repos := BinMgrRepos{}
+	_, err := client.R().
+		SetResult(&repos).
+		SetPathParam("id", binMgrId).
+		Get("xray/api/v1/binMgr/{id}/repos")
+
+	return repos, err
+}
+
+// The indexing configuration is updated with a read-modify-write cycle on the whole list,
+// so concurrent updates of the same binary manager from several resources must be serialized.
+var indexedResourcesLock sync.Mutex
+
+// moveIndexed returns the new indexed and non-indexed lists, after the 'add' items were moved to
+// the indexed list and the 'remove' items were moved to the non-indexed list.
+func moveIndexed(indexed, nonIndexed, add, remove []string) ([]string, []string) {
+	removeSet := map[string]bool{}
+	for _, name := range remove {
+		removeSet[name] = true
+	}
+	addSet := map[string]bool{}
+	for _, name := range add {
+		addSet[name] = true
+		delete(removeSet, name)
+	}
+
+	var newIndexed, newNonIndexed []string
+	seen := map[string]bool{}
+	for _, name := range append(append([]string{}, indexed...), nonIndexed...) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		wasIndexed := slices.Contains(indexed, name)
+		if addSet[name] || (wasIndexed && !removeSet[name]) {
+			newIndexed = append(newIndexed, name)
+		} else {
+			newNonIndexed = append(newNonIndexed, name)
+		}
+	}
+	for _, name := range add {
+		if !seen[name] {
+			newIndexed = append(newIndexed, name)
+		}
+	}
+
+	return newIndexed, newNonIndexed
+}
+
+// intersection returns the items of 'names' which are present in 'list', keeping the order of 'names'.
+func intersection(names, list []string) []string {
+	result := []string{}
+	for _, name := range names {
+		if slices.Contains(list, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func resourceXrayIndexedResources() *schema.Resource {
+	var indexedResourcesSchema = map[string]*schema.Schema{
+		"bin_mgr_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "default",
+			ForceNew:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "The ID of the binary manager. Default value is `default`. Use the `xray_binary_managers` data source to get the list of the available binary managers.",
+		},
+		"repos": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Names of the repositories to be indexed by Xray. The repository must exist in Artifactory. Removing a repository from the list removes it from the indexed resources.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+		},
+		"builds": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Names of the builds to be indexed by Xray. Removing a build from the list removes it from the indexed resources.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+		},
+		"build_include_patterns": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Description: "Ant-style patterns of the build names to be indexed by Xray, e.g. `release-*/**`. Builds matching the patterns are indexed automatically, including the builds published later. If not set, the current patterns remain unchanged.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+		},
+		"build_exclude_patterns": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Description: "Ant-style patterns of the build names to be excluded from `build_include_patterns`. If not set, the current patterns remain unchanged.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+		},
+		"release_bundles": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Names of the release bundles to be indexed by Xray. Removing a release bundle from the list removes it from the indexed resources.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+		},
+	}
+
+	var getBuilds = func(client *resty.Client, binMgrId string) (BinMgrBuilds, error) {
+		builds := BinMgrBuilds{}
+		_, err := client.R().
+			SetResult(&builds).
+			SetPathParam("id", binMgrId).
+			Get("xray/api/v1/binMgr/{id}/builds")
+
+		return builds, err
+	}
+
+	var getReleaseBundles = func(client *resty.Client, binMgrId string) (BinMgrReleaseBundles, error) {
+		releaseBundles := BinMgrReleaseBundles{}
+		_, err := client.R().
+			SetResult(&releaseBundles).
+			SetPathParam("id", binMgrId).
+			Get("xray/api/v1/binMgr/{id}/release_bundles")
+
+		return releaseBundles, err
+	}
+
+	var repoNames = func(repos []IndexedRepo) []string {
+		var names []string
+		for _, repo := range repos {
+			names = append(names, repo.Name)
+		}
+
+		return names
+	}
+
+	// Xray learns about new repositories asynchronously, so a repository created in the same apply
+	// may not be known to the binary manager yet. The update is retried until all the repositories are known.
+	var updateRepos = func(ctx context.Context, client *resty.Client, binMgrId string, add, remove []string, timeout time.Duration) error {
+		return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+			current, err := getBinMgrRepos(client, binMgrId)
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			all := map[string]IndexedRepo{}
+			for _, repo := range append(append([]IndexedRepo{}, current.IndexedRepos...), current.NonIndexedRepos...) {
+				all[repo.Name] = repo
+			}
+			for _, name := range add {
+				if _, ok := all[name]; !ok {
+					return resource.RetryableError(fmt.Errorf("repository '%s' is not known to the binary manager '%s'", name, binMgrId))
+				}
+			}
+
+			indexed, nonIndexed := moveIndexed(repoNames(current.IndexedRepos), repoNames(current.NonIndexedRepos), add, remove)
+			body := BinMgrRepos{
+				BinMgrId:        binMgrId,
+				IndexedRepos:    []IndexedRepo{},
+				NonIndexedRepos: []IndexedRepo{},
+			}
+			for _, name := range indexed {
+				body.IndexedRepos = append(body.IndexedRepos, all[name])
+			}
+			for _, name := range nonIndexed {
+				body.NonIndexedRepos = append(body.NonIndexedRepos, all[name])
+			}
+
+			_, err = client.R().
+				SetBody(body).
+				SetPathParam("id", binMgrId).
+				Put("xray/api/v1/binMgr/{id}/repos")
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			return nil
+		})
+	}
+
+	var updateBuilds = func(client *resty.Client, binMgrId string, add, remove []string, filters *BuildsIndexingFilters) error {
+		current, err := getBuilds(client, binMgrId)
+		if err != nil {
+			return err
+		}
+
+		indexed, nonIndexed := moveIndexed(current.IndexedBuilds, current.NonIndexedBuilds, add, remove)
+		body := BinMgrBuilds{
+			BinMgrId:         binMgrId,
+			IndexedBuilds:    append([]string{}, indexed...),
+			NonIndexedBuilds: append([]string{}, nonIndexed...),
+			IndexingFilters:  filters,
+		}
+
+		_, err = client.R().
+			SetBody(body).
+			SetPathParam("id", binMgrId).
+			Put("xray/api/v1/binMgr/{id}/builds")
+
+		return err
+	}
+
+	var updateReleaseBundles = func(client *resty.Client, binMgrId string, add, remove []string) error {
+		current, err := getReleaseBundles(client, binMgrId)
+		if err != nil {
+			return err
+		}
+
+		indexed, nonIndexed := moveIndexed(current.IndexedReleaseBundles, current.NonIndexedReleaseBundles, add, remove)
+		body := BinMgrReleaseBundles{
+			BinMgrId:                 binMgrId,
+			IndexedReleaseBundles:    append([]string{}, indexed...),
+			NonIndexedReleaseBundles: append([]string{}, nonIndexed...),
+		}
+
+		_, err = client.R().
+			SetBody(body).
+			SetPathParam("id", binMgrId).
+			Put("xray/api/v1/binMgr/{id}/release_bundles")
+
+		return err
+	}
+
+	// setDiff returns the items to add and to remove for the set attribute 'key'
+	var setDiff = func(d *schema.ResourceData, key string) ([]string, []string) {
+		o, n := d.GetChange(key)
+		oldSet, newSet := o.(*schema.Set), n.(*schema.Set)
+
+		return util.CastToStringArr(newSet.Difference(oldSet).List()), util.CastToStringArr(oldSet.Difference(newSet).List())
+	}
+
+	var unpackBuildsIndexingFilters = func(s *schema.ResourceData) *BuildsIndexingFilters {
+		if !s.HasChanges("build_include_patterns", "build_exclude_patterns") {
+			return nil
+		}
+
+		d := &util.ResourceData{ResourceData: s}
+		return &BuildsIndexingFilters{
+			IncludePatterns: d.GetSet("build_include_patterns"),
+			ExcludePatterns: d.GetSet("build_exclude_patterns"),
+		}
+	}
+
+	var applyIndexedResources = func(ctx context.Context, d *schema.ResourceData, client *resty.Client, timeout time.Duration) error {
+		indexedResourcesLock.Lock()
+		defer indexedResourcesLock.Unlock()
+
+		binMgrId := d.Get("bin_mgr_id").(string)
+
+		if d.HasChange("repos") {
+			add, remove := setDiff(d, "repos")
+			if err := updateRepos(ctx, client, binMgrId, add, remove, timeout); err != nil {
+				return err
+			}
+		}
+
+		if d.HasChanges("builds", "build_include_patterns", "build_exclude_patterns") {
+			add, remove := setDiff(d, "builds")
+			if err := updateBuilds(client, binMgrId, add, remove, unpackBuildsIndexingFilters(d)); err != nil {
+				return err
+			}
+		}
+
+		if d.HasChange("release_bundles") {
+			add, remove := setDiff(d, "release_bundles")
+			if err := updateReleaseBundles(client, binMgrId, add, remove); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Only the repositories, builds and release bundles managed by this resource are set in the state,
+	// so the resources indexed outside of Terraform don't produce a diff.
+	var resourceXrayIndexedResourcesRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client := m.(ProviderMetadata).Client
+		binMgrId := d.Get("bin_mgr_id").(string)
+
+		repos, err := getBinMgrRepos(client, binMgrId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		builds, err := getBuilds(client, binMgrId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		releaseBundles, err := getReleaseBundles(client, binMgrId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		setValue := util.MkLens(d)
+
+		setValue("bin_mgr_id", binMgrId)
+		setValue("repos", intersection(util.CastToStringArr(d.Get("repos").(*schema.Set).List()), repoNames(repos.IndexedRepos)))
+		setValue("builds", intersection(util.CastToStringArr(d.Get("builds").(*schema.Set).List()), builds.IndexedBuilds))
+		if builds.IndexingFilters != nil {
+			setValue("build_include_patterns", builds.IndexingFilters.IncludePatterns)
+			setValue("build_exclude_patterns", builds.IndexingFilters.ExcludePatterns)
+		}
+		errors := setValue("release_bundles", intersection(util.CastToStringArr(d.Get("release_bundles").(*schema.Set).List()), releaseBundles.IndexedReleaseBundles))
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack indexed resources %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceXrayIndexedResourcesCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := applyIndexedResources(ctx, d, m.(ProviderMetadata).Client, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Several resources may manage the indexed resources of the same binary manager, so the ID is made unique
+		d.SetId(resource.PrefixedUniqueId(d.Get("bin_mgr_id").(string) + "-"))
+		return resourceXrayIndexedResourcesRead(ctx, d, m)
+	}
+
+	var resourceXrayIndexedResourcesUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := applyIndexedResources(ctx, d, m.(ProviderMetadata).Client, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return resourceXrayIndexedResourcesRead(ctx, d, m)
+	}
+
+	// The managed repositories, builds and release bundles are removed from the indexed resources.
+	// The build patterns are left unchanged, because the previous patterns are unknown.
+	var resourceXrayIndexedResourcesDelete = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client := m.(ProviderMetadata).Client
+		binMgrId := d.Get("bin_mgr_id").(string)
+
+		indexedResourcesLock.Lock()
+		defer indexedResourcesLock.Unlock()
+
+		if repos := util.CastToStringArr(d.Get("repos").(*schema.Set).List()); len(repos) > 0 {
+			if err := updateRepos(ctx, client, binMgrId, nil, repos, d.Timeout(schema.TimeoutDelete)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if builds := util.CastToStringArr(d.Get("builds").(*schema.Set).List()); len(builds) > 0 {
+			if err := updateBuilds(client, binMgrId, nil, builds, nil); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if releaseBundles := util.CastToStringArr(d.Get("release_bundles").(*schema.Set).List()); len(releaseBundles) > 0 {
+			if err := updateReleaseBundles(client, binMgrId, nil, releaseBundles); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	// Import adopts all the currently indexed repositories, builds and release bundles of the binary manager,
+	// the import ID being the ID of the binary manager.
+	var resourceXrayIndexedResourcesImport = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		client := m.(ProviderMetadata).Client
+		binMgrId := d.Id()
+
+		repos, err := getBinMgrRepos(client, binMgrId)
+		if err != nil {
+			return nil, err
+		}
+		builds, err := getBuilds(client, binMgrId)
+		if err != nil {
+			return nil, err
+		}
+		releaseBundles, err := getReleaseBundles(client, binMgrId)
+		if err != nil {
+			return nil, err
+		}
+
+		setValue := util.MkLens(d)
+
+		setValue("bin_mgr_id", binMgrId)
+		setValue("repos", repoNames(repos.IndexedRepos))
+		setValue("builds", builds.IndexedBuilds)
+		errors := setValue("release_bundles", releaseBundles.IndexedReleaseBundles)
+
+		if len(errors) > 0 {
+			return nil, fmt.Errorf("failed to import indexed resources %q", errors)
+		}
+
+		d.SetId(resource.PrefixedUniqueId(binMgrId + "-"))
+		return []*schema.ResourceData{d}, nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayIndexedResourcesCreate,
+		ReadContext:   resourceXrayIndexedResourcesRead,
+		UpdateContext: resourceXrayIndexedResourcesUpdate,
+		DeleteContext: resourceXrayIndexedResourcesDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceXrayIndexedResourcesImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: indexedResourcesSchema,
+		Description: "Provides an Xray indexed resources resource. Adds repositories, builds and release bundles of a binary manager to the resources indexed by Xray, " +
+			"which is required before they can be used in `xray_watch`. Resources indexed outside of Terraform are left unchanged. " +
+			"Several resources can manage the indexed resources of the same binary manager, as long as they don't list the same repositories, builds or release bundles. " +
+			"See [Xray Indexing Resources](https://www.jfrog.com/confluence/display/JFROG/Indexing+Xray+Resources) and [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-UpdateBinaryManagerReposIndexingConfiguration) for more details.",
+	}
+}
@@ -0,0 +1,88 @@
+package xray
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const indexedResourcesTemplate = `
+resource "xray_indexed_resources" "{{ .resource_name }}" {
+  bin_mgr_id = "default"
+  repos      = [{{ .repos }}]
+}
+`
+
+func TestAccIndexedResources_repos(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("indexed-resources-", "xray_indexed_resources")
+	repo1 := fmt.Sprintf("indexed-repo-%d", test.RandomInt())
+	repo2 := fmt.Sprintf("indexed-repo-%d", test.RandomInt())
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"repos":         fmt.Sprintf(`"%s"`, repo1),
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["repos"] = fmt.Sprintf(`"%s", "%s"`, repo1, repo2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCreateRepos(t, repo1, "local", "")
+			testAccCreateRepos(t, repo2, "local", "")
+		},
+		CheckDestroy: verifyDeleted(fqrn, func(id string, request *resty.Request) (*resty.Response, error) {
+			testAccDeleteRepo(t, repo1)
+			testAccDeleteRepo(t, repo2)
+			return dummyError(), fmt.Errorf("repos were deleted")
+		}),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, indexedResourcesTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "bin_mgr_id", "default"),
+					resource.TestCheckResourceAttr(fqrn, "repos.#", "1"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "repos.*", repo1),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, indexedResourcesTemplate, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "repos.#", "2"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "repos.*", repo1),
+					resource.TestCheckTypeSetElemAttr(fqrn, "repos.*", repo2),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, indexedResourcesTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "repos.#", "1"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "repos.*", repo1),
+				),
+			},
+		},
+	})
+}
+
+func TestMoveIndexed(t *testing.T) {
+	indexed, nonIndexed := moveIndexed(
+		[]string{"repo-1", "repo-2"},
+		[]string{"repo-3", "repo-4"},
+		[]string{"repo-3", "repo-5"},
+		[]string{"repo-1", "repo-4"},
+	)
+
+	expectedIndexed := []string{"repo-2", "repo-3", "repo-5"}
+	expectedNonIndexed := []string{"repo-1", "repo-4"}
+	if fmt.Sprint(indexed) != fmt.Sprint(expectedIndexed) {
+		t.Errorf("expected indexed %v, got %v", expectedIndexed, indexed)
+	}
+	if fmt.Sprint(nonIndexed) != fmt.Sprint(expectedNonIndexed) {
+		t.Errorf("expected non-indexed %v, got %v", expectedNonIndexed, nonIndexed)
+	}
+}
@@ -0,0 +1,293 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+type JiraFieldMapping struct {
+	XrayField string `json:"xray_field"`
+	JiraField string `json:"jira_field"`
+}
+
+type JiraIntegration struct {
+	ConnectionName   string             `json:"connection_name"`
+	InstallationType string             `json:"installation_type"`
+	Url              string             `json:"url"`
+	AuthType         string             `json:"auth_type"`
+	UserName         string             `json:"username,omitempty"`
+	Password         string             `json:"password,omitempty"`
+	SkipProxy        bool               `json:"skip_proxy"`
+	Projects         []string           `json:"projects"`
+	IssueTypes       []string           `json:"issue_types"`
+	Labels           []string           `json:"labels,omitempty"`
+	FieldMappings    []JiraFieldMapping `json:"field_mappings,omitempty"`
+}
+
+func resourceXrayJiraIntegration() *schema.Resource {
+	var jiraIntegrationSchema = map[string]*schema.Schema{
+		"name": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Name of the Jira connection (must be unique).",
+		},
+		"url": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+			Description:      "URL of the Jira server.",
+		},
+		"installation_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "cloud",
+			ValidateDiagFunc: validator.StringInSlice(true, "cloud", "server"),
+			DiffSuppressFunc: ignoreCaseDiffSuppress,
+			Description:      "Type of the Jira installation. Options: `cloud` or `server`. Default value is `cloud`.",
+		},
+		"auth_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "basic",
+			ValidateDiagFunc: validator.StringInSlice(true, "basic", "bearer"),
+			DiffSuppressFunc: ignoreCaseDiffSuppress,
+			Description:      "Authentication method against the Jira server. Options: `basic` (user name and password or API token) or `bearer` (personal access token). Default value is `basic`.",
+		},
+		"username": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "User name for the Jira server. Required when `auth_type` is `basic`.",
+		},
+		"password": {
+			Type:             schema.TypeString,
+			Required:         true,
+			Sensitive:        true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Password, API token or personal access token for the Jira server. Xray does not return the password, so changes made outside of Terraform are not detected.",
+		},
+		"skip_proxy": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Do not use the proxy configured in Xray to reach the Jira server. Default value is `false`.",
+		},
+		"projects": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Description: "Keys of the Jira projects Xray is allowed to create tickets in.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+		},
+		"issue_types": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Description: "Jira issue types Xray is allowed to use when creating tickets, e.g. `Bug` or `Task`.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+		},
+		"labels": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Labels added to every ticket created by Xray.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+		},
+		"field_mapping": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Mapping of Xray violation fields to Jira fields, used to populate custom fields of the created tickets.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"xray_field": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: validator.StringIsNotEmpty,
+						Description:      "Name of the Xray violation field, e.g. `severity` or `cve`.",
+					},
+					"jira_field": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: validator.StringIsNotEmpty,
+						Description:      "ID of the Jira field, e.g. `customfield_10010`.",
+					},
+				},
+			},
+		},
+	}
+
+	var unpackFieldMappings = func(d *schema.ResourceData) []JiraFieldMapping {
+		var mappings []JiraFieldMapping
+		if v, ok := d.GetOk("field_mapping"); ok {
+			for _, raw := range v.(*schema.Set).List() {
+				m := raw.(map[string]interface{})
+				mappings = append(mappings, JiraFieldMapping{
+					XrayField: m["xray_field"].(string),
+					JiraField: m["jira_field"].(string),
+				})
+			}
+		}
+
+		return mappings
+	}
+
+	var unpackJiraIntegration = func(s *schema.ResourceData) JiraIntegration {
+		d := &util.ResourceData{ResourceData: s}
+
+		return JiraIntegration{
+			ConnectionName:   d.GetString("name", false),
+			Url:              d.GetString("url", false),
+			InstallationType: d.GetString("installation_type", false),
+			AuthType:         d.GetString("auth_type", false),
+			UserName:         d.GetString("username", false),
+			Password:         d.GetString("password", false),
+			SkipProxy:        d.GetBool("skip_proxy", false),
+			Projects:         d.GetSet("projects"),
+			IssueTypes:       d.GetSet("issue_types"),
+			Labels:           d.GetSet("labels"),
+			FieldMappings:    unpackFieldMappings(s),
+		}
+	}
+
+	var packFieldMappings = func(mappings []JiraFieldMapping) []interface{} {
+		var ms []interface{}
+
+		for _, mapping := range mappings {
+			ms = append(ms, map[string]interface{}{
+				"xray_field": mapping.XrayField,
+				"jira_field": mapping.JiraField,
+			})
+		}
+
+		return ms
+	}
+
+	// 'password' is not packed, as Xray doesn't return its value
+	var packJiraIntegration = func(integration JiraIntegration, d *schema.ResourceData) diag.Diagnostics {
+		setValue := util.MkLens(d)
+
+		setValue("name", integration.ConnectionName)
+		setValue("url", integration.Url)
+		setValue("installation_type", integration.InstallationType)
+		setValue("auth_type", integration.AuthType)
+		setValue("username", integration.UserName)
+		setValue("skip_proxy", integration.SkipProxy)
+		setValue("projects", integration.Projects)
+		setValue("issue_types", integration.IssueTypes)
+		setValue("labels", integration.Labels)
+		errors := setValue("field_mapping", packFieldMappings(integration.FieldMappings))
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack Jira integration %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceXrayJiraIntegrationRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		integration := JiraIntegration{}
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetResult(&integration).
+			SetPathParam("name", d.Id()).
+			Get("xray/api/v1/ticketing/jira-integrations/{name}")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray Jira integration (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return packJiraIntegration(integration, d)
+	}
+
+	var resourceXrayJiraIntegrationCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		integration := unpackJiraIntegration(d)
+
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(integration).
+			Post("xray/api/v1/ticketing/jira-integrations")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(integration.ConnectionName)
+		return resourceXrayJiraIntegrationRead(ctx, d, m)
+	}
+
+	var resourceXrayJiraIntegrationUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		integration := unpackJiraIntegration(d)
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetBody(integration).
+			SetPathParam("name", d.Id()).
+			Put("xray/api/v1/ticketing/jira-integrations/{name}")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray Jira integration (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return resourceXrayJiraIntegrationRead(ctx, d, m)
+	}
+
+	var resourceXrayJiraIntegrationDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetPathParam("name", d.Id()).
+			Delete("xray/api/v1/ticketing/jira-integrations/{name}")
+		if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	var jiraIntegrationDiff = func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		authType := diff.Get("auth_type").(string)
+		username := diff.Get("username").(string)
+		if strings.EqualFold(authType, "basic") && len(username) == 0 {
+			return fmt.Errorf("attribute 'username' must be set when 'auth_type' is set to 'basic'")
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayJiraIntegrationCreate,
+		ReadContext:   resourceXrayJiraIntegrationRead,
+		UpdateContext: resourceXrayJiraIntegrationUpdate,
+		DeleteContext: resourceXrayJiraIntegrationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: jiraIntegrationDiff,
+
+		Schema:      jiraIntegrationSchema,
+		Description: "Provides an Xray Jira integration resource. The integration is required by the policy `actions.create_ticket_enabled` attribute. See [Xray Jira Integration](https://www.jfrog.com/confluence/display/JFROG/Xray+Jira+Integration) for more details.",
+	}
+}
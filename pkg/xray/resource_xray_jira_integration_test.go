@@ -0,0 +1,107 @@
+package xray
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const jiraIntegrationTemplate = `
+	resource "xray_jira_integration" "{{ .resource_name }}" {
+	  name              = "{{ .integration_name }}"
+	  url               = "{{ .url }}"
+	  installation_type = "cloud"
+	  auth_type         = "{{ .auth_type }}"
+	  {{ if .username }}username = "{{ .username }}"{{ end }}
+	  password          = "fake-token"
+	  projects          = ["{{ .project }}"]
+	  issue_types       = ["Bug"]
+	  labels            = ["xray"]
+
+	  field_mapping {
+	    xray_field = "severity"
+	    jira_field = "customfield_10010"
+	  }
+	}
+`
+
+func TestAccJiraIntegration_full(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("jira-", "xray_jira_integration")
+
+	testData := map[string]string{
+		"resource_name":    resourceName,
+		"integration_name": fmt.Sprintf("jira-%d", test.RandomInt()),
+		"url":              "https://example.atlassian.net",
+		"auth_type":        "basic",
+		"username":         "xray@example.com",
+		"project":          "SEC",
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["project"] = "OPS"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckJiraIntegration),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, jiraIntegrationTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "name", testData["integration_name"]),
+					resource.TestCheckResourceAttr(fqrn, "url", testData["url"]),
+					resource.TestCheckResourceAttr(fqrn, "username", testData["username"]),
+					resource.TestCheckTypeSetElemAttr(fqrn, "projects.*", testData["project"]),
+					resource.TestCheckTypeSetElemAttr(fqrn, "issue_types.*", "Bug"),
+					resource.TestCheckResourceAttr(fqrn, "field_mapping.#", "1"),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, jiraIntegrationTemplate, updatedTestData),
+				Check:  resource.TestCheckTypeSetElemAttr(fqrn, "projects.*", updatedTestData["project"]),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func TestAccJiraIntegration_basicAuthWithoutUsername(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("jira-", "xray_jira_integration")
+
+	testData := map[string]string{
+		"resource_name":    resourceName,
+		"integration_name": fmt.Sprintf("jira-%d", test.RandomInt()),
+		"url":              "https://example.atlassian.net",
+		"auth_type":        "Basic",
+		"username":         "",
+		"project":          "SEC",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, jiraIntegrationTemplate, testData),
+				ExpectError: regexp.MustCompile(`attribute 'username' must be set when 'auth_type' is set to 'basic'`),
+			},
+		},
+	})
+}
+
+func testCheckJiraIntegration(id string, request *resty.Request) (*resty.Response, error) {
+	return request.
+		AddRetryCondition(client.NeverRetry).
+		SetPathParam("name", id).
+		Get("xray/api/v1/ticketing/jira-integrations/{name}")
+}
@@ -11,19 +11,19 @@ func resourceXrayLicensePolicyV2() *schema.Resource {
 		"banned_licenses": {
 			Type:        schema.TypeSet,
 			Optional:    true,
-			Description: "A list of OSS license names that may not be attached to a component.",
+			Description: "A list of OSS license names that may not be attached to a component. Names outside of the SPDX license list raise a warning, and must match a custom license created with `xray_custom_license`.",
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
-				ValidateDiagFunc: validator.LicenseType,
+				ValidateDiagFunc: licenseName,
 			},
 		},
 		"allowed_licenses": {
 			Type:        schema.TypeSet,
 			Optional:    true,
-			Description: "A list of OSS license names that may be attached to a component.",
+			Description: "A list of OSS license names that may be attached to a component. Names outside of the SPDX license list raise a warning, and must match a custom license created with `xray_custom_license`.",
 			Elem: &schema.Schema{
 				Type:             schema.TypeString,
-				ValidateDiagFunc: validator.LicenseType,
+				ValidateDiagFunc: licenseName,
 			},
 		},
 		"allow_unknown": {
@@ -60,12 +60,14 @@ func resourceXrayLicensePolicyV2() *schema.Resource {
 		UpdateContext: resourceXrayPolicyUpdate,
 		DeleteContext: resourceXrayPolicyDelete,
 		Description: "Creates an Xray policy using V2 of the underlying APIs. Please note: " +
-			"It's only compatible with Bearer token auth method (Identity and Access => Access Tokens)",
+			"It's only compatible with Bearer token auth method (Identity and Access => Access Tokens, or `oidc_provider_name`)",
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: policyAuthDiff,
+
 		Schema: getPolicySchema(criteriaSchema, actionsSchema),
 	}
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/jfrog/terraform-provider-shared/test"
 	"github.com/jfrog/terraform-provider-shared/util"
@@ -35,6 +37,19 @@ var testDataLicense = map[string]string{
 }
 
 // License policy criteria are different from the security policy criteria
+func TestLicenseName(t *testing.T) {
+	path := cty.GetAttrPath("banned_licenses")
+
+	if diags := licenseName("Apache-2.0", path); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for the SPDX license, got: %v", diags)
+	}
+
+	diags := licenseName("my-custom-license", path)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning for the custom license, got: %v", diags)
+	}
+}
+
 // Test will try to post a new license policy with incorrect body of security policy
 // with specified cvss_range. The function unpackLicenseCriteria will ignore all the
 // fields except of "allow_unknown", "banned_licenses" and "allowed_licenses" if the Policy type is "license"
@@ -0,0 +1,134 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const offlineUpdateSourceId = "xray_offline_update_source"
+
+var offlineUpdateComponentTypes = []string{"vulnerabilities", "components", "licenses", "exposures", "contextual_analysis"}
+
+type OfflineUpdateSource struct {
+	Enabled        bool     `json:"enabled"`
+	Path           string   `json:"path"`
+	ComponentTypes []string `json:"component_types"`
+}
+
+func resourceXrayOfflineUpdateSource() *schema.Resource {
+	var unpackOfflineUpdateSource = func(s *schema.ResourceData) OfflineUpdateSource {
+		d := &util.ResourceData{ResourceData: s}
+
+		return OfflineUpdateSource{
+			Enabled:        d.GetBool("enabled", false),
+			Path:           d.GetString("path", false),
+			ComponentTypes: d.GetSet("component_types"),
+		}
+	}
+
+	var packOfflineUpdateSource = func(offlineUpdateSource OfflineUpdateSource, d *schema.ResourceData) diag.Diagnostics {
+		setValue := util.MkLens(d)
+
+		setValue("enabled", offlineUpdateSource.Enabled)
+		setValue("path", offlineUpdateSource.Path)
+		errors := setValue("component_types", offlineUpdateSource.ComponentTypes)
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack offline update source %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceXrayOfflineUpdateSourceRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		offlineUpdateSource := OfflineUpdateSource{}
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetResult(&offlineUpdateSource).
+			Get("xray/api/v1/configuration/offline_updates")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray offline update source (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return packOfflineUpdateSource(offlineUpdateSource, d)
+	}
+
+	var resourceXrayOfflineUpdateSourceUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		offlineUpdateSource := unpackOfflineUpdateSource(d)
+
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(offlineUpdateSource).
+			Put("xray/api/v1/configuration/offline_updates")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(offlineUpdateSourceId)
+		return resourceXrayOfflineUpdateSourceRead(ctx, d, m)
+	}
+
+	// The offline updates are disabled on destroy, so Xray stops polling the path
+	var resourceXrayOfflineUpdateSourceDelete = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		offlineUpdateSource := unpackOfflineUpdateSource(d)
+		offlineUpdateSource.Enabled = false
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetBody(offlineUpdateSource).
+			Put("xray/api/v1/configuration/offline_updates")
+		if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayOfflineUpdateSourceUpdate,
+		ReadContext:   resourceXrayOfflineUpdateSourceRead,
+		UpdateContext: resourceXrayOfflineUpdateSourceUpdate,
+		DeleteContext: resourceXrayOfflineUpdateSourceDelete,
+		Description: "Provides an Xray offline update source resource. Configures the path, from which Xray imports the offline DB updates in the air-gapped installations. " +
+			"The updates are downloaded with the JFrog CLI `jf xr offline-update` command. Only one instance of the resource should be declared.",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enables the import of the offline updates. The updates are disabled when the resource is destroyed. Default value is `true`.",
+			},
+			"path": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Path on the Xray server, where the offline update files are placed.",
+			},
+			"component_types": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validator.StringInSlice(true, offlineUpdateComponentTypes...),
+				},
+				Description: fmt.Sprintf("Types of the data imported from the offline updates. Allowed values: %q.", offlineUpdateComponentTypes),
+			},
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package xray
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const offlineUpdateSourceTemplate = `
+resource "xray_offline_update_source" "{{ .resource_name }}" {
+  path            = "{{ .path }}"
+  component_types = [{{ .component_types }}]
+}
+`
+
+func TestAccOfflineUpdateSource(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("offline-update-source-", "xray_offline_update_source")
+
+	testData := map[string]string{
+		"resource_name":   resourceName,
+		"path":            "/opt/jfrog/xray/offline-updates",
+		"component_types": `"vulnerabilities", "components"`,
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["path"] = "/opt/jfrog/xray/offline-updates-v2"
+	updatedTestData["component_types"] = `"vulnerabilities", "components", "licenses"`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, offlineUpdateSourceTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "enabled", "true"),
+					resource.TestCheckResourceAttr(fqrn, "path", testData["path"]),
+					resource.TestCheckResourceAttr(fqrn, "component_types.#", "2"),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, offlineUpdateSourceTemplate, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "path", updatedTestData["path"]),
+					resource.TestCheckResourceAttr(fqrn, "component_types.#", "3"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "component_types.*", "licenses"),
+				),
+			},
+			{
+				ResourceName:      fqrn,
+				ImportState:       true,
+				ImportStateId:     "xray_offline_update_source",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccOfflineUpdateSource_invalidComponentType(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("offline-update-source-", "xray_offline_update_source")
+
+	testData := map[string]string{
+		"resource_name":   resourceName,
+		"path":            "/opt/jfrog/xray/offline-updates",
+		"component_types": `"vulnerabilities", "invalid"`,
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, offlineUpdateSourceTemplate, testData),
+				ExpectError: regexp.MustCompile(`expected component_types.* to be one of`),
+			},
+		},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jfrog/terraform-provider-shared/validator"
@@ -113,13 +114,13 @@ func resourceXrayOperationalRiskPolicy() *schema.Resource {
 		UpdateContext: resourceXrayPolicyUpdate,
 		DeleteContext: resourceXrayPolicyDelete,
 		Description: "Creates an Xray policy using V2 of the underlying APIs. Please note: " +
-			"It's only compatible with Bearer token auth method (Identity and Access => Access Tokens)",
+			"It's only compatible with Bearer token auth method (Identity and Access => Access Tokens, or `oidc_provider_name`)",
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		CustomizeDiff: criteriaDiff,
+		CustomizeDiff: customdiff.All(criteriaDiff, policyAuthDiff),
 
 		Schema: getPolicySchema(criteriaSchema, commonActionsSchema),
 	}
@@ -0,0 +1,161 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const proxySettingsId = "xray_proxy_settings"
+
+type ProxySettings struct {
+	Enabled      bool     `json:"enabled"`
+	Url          string   `json:"url"`
+	Port         int      `json:"port"`
+	Username     string   `json:"username,omitempty"`
+	Password     string   `json:"password,omitempty"`
+	NoProxyHosts []string `json:"no_proxy_hosts"`
+}
+
+func resourceXrayProxySettings() *schema.Resource {
+	var unpackProxySettings = func(s *schema.ResourceData) ProxySettings {
+		d := &util.ResourceData{ResourceData: s}
+
+		return ProxySettings{
+			Enabled:      d.GetBool("enabled", false),
+			Url:          d.GetString("url", false),
+			Port:         d.GetInt("port", false),
+			Username:     d.GetString("username", false),
+			Password:     d.GetString("password", false),
+			NoProxyHosts: d.GetSet("no_proxy_hosts"),
+		}
+	}
+
+	var packProxySettings = func(proxySettings ProxySettings, d *schema.ResourceData) diag.Diagnostics {
+		setValue := util.MkLens(d)
+
+		setValue("enabled", proxySettings.Enabled)
+		setValue("url", proxySettings.Url)
+		setValue("port", proxySettings.Port)
+		setValue("username", proxySettings.Username)
+		errors := setValue("no_proxy_hosts", proxySettings.NoProxyHosts)
+		// 'password' is not packed, as Xray doesn't return its value
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack proxy settings %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceXrayProxySettingsRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		proxySettings := ProxySettings{}
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetResult(&proxySettings).
+			Get("xray/api/v1/configuration/proxy")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray proxy settings (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return packProxySettings(proxySettings, d)
+	}
+
+	var resourceXrayProxySettingsUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		proxySettings := unpackProxySettings(d)
+
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(proxySettings).
+			Put("xray/api/v1/configuration/proxy")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(proxySettingsId)
+		return resourceXrayProxySettingsRead(ctx, d, m)
+	}
+
+	// The proxy is disabled on destroy, the credentials are cleared
+	var resourceXrayProxySettingsDelete = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		proxySettings := unpackProxySettings(d)
+		proxySettings.Enabled = false
+		proxySettings.Username = ""
+		proxySettings.Password = ""
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetBody(proxySettings).
+			Put("xray/api/v1/configuration/proxy")
+		if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayProxySettingsUpdate,
+		ReadContext:   resourceXrayProxySettingsRead,
+		UpdateContext: resourceXrayProxySettingsUpdate,
+		DeleteContext: resourceXrayProxySettingsDelete,
+		Description: "Provides an Xray proxy settings resource. Configures the HTTP proxy, used by Xray for the outbound traffic, e.g. the DB sync with the JFrog global database, " +
+			"Jira integration and webhooks. Only one instance of the resource should be declared.",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enables the proxy. The proxy is disabled when the resource is destroyed. Default value is `true`.",
+			},
+			"url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "URL of the proxy server, e.g. `http://proxy.mycompany.com`.",
+			},
+			"port": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsPortNumber),
+				Description:      "Port of the proxy server.",
+			},
+			"username": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"password"},
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "User name for the proxy server.",
+			},
+			"password": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				RequiredWith:     []string{"username"},
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Password for the proxy server. Xray does not return the password, so changes made outside of Terraform are not detected.",
+			},
+			"no_proxy_hosts": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Hosts, which are reached without the proxy. Wildcards are supported, e.g. `*.mycompany.com`.",
+			},
+		},
+	}
+}
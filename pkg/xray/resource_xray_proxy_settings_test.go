@@ -0,0 +1,79 @@
+package xray
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const proxySettingsTemplate = `
+resource "xray_proxy_settings" "{{ .resource_name }}" {
+  url            = "http://proxy.mycompany.com"
+  port           = {{ .port }}
+  username       = "proxy-user"
+  password       = "proxy-password"
+  no_proxy_hosts = ["localhost", "*.mycompany.com"]
+}
+`
+
+func TestAccProxySettings(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("proxy-settings-", "xray_proxy_settings")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"port":          "8080",
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["port"] = "3128"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, proxySettingsTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "enabled", "true"),
+					resource.TestCheckResourceAttr(fqrn, "url", "http://proxy.mycompany.com"),
+					resource.TestCheckResourceAttr(fqrn, "port", testData["port"]),
+					resource.TestCheckResourceAttr(fqrn, "username", "proxy-user"),
+					resource.TestCheckResourceAttr(fqrn, "no_proxy_hosts.#", "2"),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, proxySettingsTemplate, updatedTestData),
+				Check:  resource.TestCheckResourceAttr(fqrn, "port", updatedTestData["port"]),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateId:           "xray_proxy_settings",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func TestAccProxySettings_invalidPort(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("proxy-settings-", "xray_proxy_settings")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"port":          "70000",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, proxySettingsTemplate, testData),
+				ExpectError: regexp.MustCompile(`expected "port" to be a valid port number`),
+			},
+		},
+	})
+}
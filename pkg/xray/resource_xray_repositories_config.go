@@ -0,0 +1,479 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+	"golang.org/x/exp/slices"
+)
+
+// indexableRepositoryTypes are the types of the Artifactory repositories, which can be indexed by Xray
+var indexableRepositoryTypes = []string{"local", "remote", "federated"}
+
+type ArtifactoryRepository struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	PackageType string `json:"packageType"`
+}
+
+func getArtifactoryRepository(client *resty.Client, repoName string) (ArtifactoryRepository, error) {
+	repo := ArtifactoryRepository{}
+
+	_, err := client.R().
+		SetResult(&repo).
+		SetPathParam("repo_name", repoName).
+		Get("artifactory/api/repositories/{repo_name}")
+
+	return repo, err
+}
+
+func getArtifactoryRepositories(client *resty.Client) ([]ArtifactoryRepository, error) {
+	var repos []ArtifactoryRepository
+
+	_, err := client.R().
+		SetResult(&repos).
+		Get("artifactory/api/repositories")
+
+	return repos, err
+}
+
+// forEachRepo calls 'fn' for every repository, with at most 'parallelism' concurrent calls,
+// started at no more than 'rateLimit' calls per second. All the errors are collected.
+func forEachRepo(ctx context.Context, repoNames []string, parallelism, rateLimit int, fn func(repoName string) error) []error {
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		errors []error
+	)
+
+	semaphore := make(chan struct{}, parallelism)
+	ticker := time.NewTicker(time.Second / time.Duration(rateLimit))
+	defer ticker.Stop()
+
+	for _, repoName := range repoNames {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return append(errors, ctx.Err())
+		case <-ticker.C:
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return append(errors, ctx.Err())
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(repoName string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			if err := fn(repoName); err != nil {
+				mutex.Lock()
+				errors = append(errors, fmt.Errorf("repo (%s): %w", repoName, err))
+				mutex.Unlock()
+			}
+		}(repoName)
+	}
+	wg.Wait()
+
+	return errors
+}
+
+// isRepositoryConfigApplied compares only the fields, which are configured by the resource,
+// so the defaults added by Xray don't make the configuration look as not applied.
+func isRepositoryConfigApplied(expected, actual RepositoryConfiguration) bool {
+	if expected.RepoConfig != nil {
+		if actual.RepoConfig == nil || actual.RepoConfig.RetentionInDays != expected.RepoConfig.RetentionInDays {
+			return false
+		}
+		// 'vuln_contextual_analysis' is omitted by Xray, if it's not supported
+		if expected.RepoConfig.VulnContextualAnalysis && !actual.RepoConfig.VulnContextualAnalysis {
+			return false
+		}
+	}
+
+	if expected.RepoPathsConfig != nil {
+		if actual.RepoPathsConfig == nil ||
+			actual.RepoPathsConfig.OtherArtifacts != expected.RepoPathsConfig.OtherArtifacts ||
+			len(actual.RepoPathsConfig.Patterns) != len(expected.RepoPathsConfig.Patterns) {
+			return false
+		}
+
+		for _, expectedPattern := range expected.RepoPathsConfig.Patterns {
+			if slices.IndexFunc(actual.RepoPathsConfig.Patterns, func(actualPattern Pattern) bool {
+				return actualPattern.Include == expectedPattern.Include &&
+					(len(expectedPattern.Exclude) == 0 || actualPattern.Exclude == expectedPattern.Exclude) &&
+					actualPattern.IndexNewArtifacts == expectedPattern.IndexNewArtifacts &&
+					actualPattern.RetentionInDays == expectedPattern.RetentionInDays
+			}) < 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func resourceXrayRepositoriesConfig() *schema.Resource {
+	var repositoriesConfigSchema = util.MergeMaps(
+		map[string]*schema.Schema{
+			"repo_names": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "Names of the repositories to configure.",
+				AtLeastOneOf: []string{"repo_names", "repo_include_pattern"},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validator.StringIsNotEmpty,
+				},
+			},
+			"repo_include_pattern": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "Regular expression, matched against the keys of the Artifactory repositories. Matching repositories are configured in addition to `repo_names`, e.g. `^docker-.*`. Only the local, remote and federated repositories indexed by Xray (binary manager `default`) are matched, the other matching repositories are skipped with a warning.",
+			},
+			"repo_exclude_pattern": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"repo_include_pattern"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "Regular expression, matched against the keys of the Artifactory repositories. Matching repositories are excluded from the `repo_include_pattern` results.",
+			},
+			"parallelism": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 50)),
+				Description:      "Maximum number of concurrent requests to Xray. Default value is `10`.",
+			},
+			"rate_limit": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          20,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 1000)),
+				Description:      "Maximum number of requests to Xray per second. Default value is `20`.",
+			},
+			"package_type_config": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Configuration applied to the repositories of the given package type, instead of `config` or `paths_config`. Allows to set defaults per package type, e.g. 30 days retention for Docker and 365 days for Maven repositories. Repositories without a matching `package_type_config`, `config` or `paths_config` are left unchanged.",
+				Elem:        packageTypeConfigElem,
+			},
+			"repos": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Names of the repositories, which have the configuration applied. Repositories with a different configuration in Xray are removed from the list, so they are updated on the next apply.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		repoConfigBlocksSchema,
+		getProjectKeySchema(false, "All the repositories must be assigned to the project."),
+	)
+
+	// resolveRepositoryConfigs returns the configuration for each of the selected repositories, sorted by the repository name.
+	// Only the repositories indexed by Xray are matched by 'repo_include_pattern', the names of the other matching repositories
+	// are returned separately, so they can be reported.
+	// 'd' is either *schema.ResourceData or *schema.ResourceDiff.
+	var resolveRepositoryConfigs = func(client *resty.Client, d interface{ Get(string) interface{} }) ([]RepositoryConfiguration, []string, error) {
+		repoNames := util.CastToStringArr(d.Get("repo_names").(*schema.Set).List())
+		includePattern := d.Get("repo_include_pattern").(string)
+		excludePattern := d.Get("repo_exclude_pattern").(string)
+		packageTypeConfigs, err := unpackPackageTypeConfigs(d.Get("package_type_config").(*schema.Set))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		packageTypes := map[string]string{}
+		var notIndexedRepoNames []string
+		if len(includePattern) > 0 || len(packageTypeConfigs) > 0 {
+			artifactoryRepos, err := getArtifactoryRepositories(client)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var includeRegexp, excludeRegexp *regexp.Regexp
+			indexedRepoNames := map[string]bool{}
+			if len(includePattern) > 0 {
+				if includeRegexp, err = regexp.Compile(includePattern); err != nil {
+					return nil, nil, err
+				}
+
+				binMgrRepos, err := getBinMgrRepos(client, "default")
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, repo := range binMgrRepos.IndexedRepos {
+					indexedRepoNames[repo.Name] = true
+				}
+			}
+			if len(excludePattern) > 0 {
+				if excludeRegexp, err = regexp.Compile(excludePattern); err != nil {
+					return nil, nil, err
+				}
+			}
+
+			for _, artifactoryRepo := range artifactoryRepos {
+				packageTypes[artifactoryRepo.Key] = strings.ToLower(artifactoryRepo.PackageType)
+
+				// Virtual repositories are never indexed by Xray
+				if !slices.Contains(indexableRepositoryTypes, strings.ToLower(artifactoryRepo.Type)) {
+					continue
+				}
+
+				if includeRegexp != nil && includeRegexp.MatchString(artifactoryRepo.Key) &&
+					(excludeRegexp == nil || !excludeRegexp.MatchString(artifactoryRepo.Key)) &&
+					!slices.Contains(repoNames, artifactoryRepo.Key) {
+					if !indexedRepoNames[artifactoryRepo.Key] {
+						notIndexedRepoNames = append(notIndexedRepoNames, artifactoryRepo.Key)
+						continue
+					}
+					repoNames = append(repoNames, artifactoryRepo.Key)
+				}
+			}
+		}
+		sort.Strings(repoNames)
+		sort.Strings(notIndexedRepoNames)
+
+		var repoConfig *RepoConfiguration
+		if config := d.Get("config").(*schema.Set); config.Len() > 0 {
+			repoConfig = unpackRepoConfig(config)
+		}
+		repoPathsConfig := unpackRepoPathConfig(d.Get("paths_config").(*schema.Set))
+
+		var repositoryConfigs []RepositoryConfiguration
+		for _, repoName := range repoNames {
+			repositoryConfig := RepositoryConfiguration{
+				RepoName:        repoName,
+				RepoConfig:      repoConfig,
+				RepoPathsConfig: repoPathsConfig,
+			}
+
+			if packageTypeConfig, ok := packageTypeConfigs[packageTypes[repoName]]; ok {
+				repositoryConfig.RepoConfig = packageTypeConfig
+				repositoryConfig.RepoPathsConfig = nil
+			}
+
+			// Repositories without a matching configuration are left unchanged
+			if repositoryConfig.RepoConfig == nil && repositoryConfig.RepoPathsConfig == nil {
+				continue
+			}
+
+			repositoryConfigs = append(repositoryConfigs, repositoryConfig)
+		}
+
+		return repositoryConfigs, notIndexedRepoNames, nil
+	}
+
+	var repositoryConfigNames = func(repositoryConfigs []RepositoryConfiguration) []string {
+		names := []string{}
+		for _, repositoryConfig := range repositoryConfigs {
+			names = append(names, repositoryConfig.RepoName)
+		}
+
+		return names
+	}
+
+	var resourceXrayRepositoriesConfigRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client := m.(ProviderMetadata).Client
+		projectKey := d.Get("project_key").(string)
+
+		repositoryConfigs, _, err := resolveRepositoryConfigs(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		expectedConfigs := map[string]RepositoryConfiguration{}
+		for _, repositoryConfig := range repositoryConfigs {
+			expectedConfigs[repositoryConfig.RepoName] = repositoryConfig
+		}
+
+		var (
+			mutex        sync.Mutex
+			appliedRepos []string
+		)
+		repoNames := util.CastToStringArr(d.Get("repos").(*schema.Set).List())
+		errors := forEachRepo(ctx, repoNames, d.Get("parallelism").(int), d.Get("rate_limit").(int), func(repoName string) error {
+			expectedConfig, ok := expectedConfigs[repoName]
+			if !ok {
+				return nil
+			}
+
+			req, err := getRestyRequest(m.(ProviderMetadata), projectKey)
+			if err != nil {
+				return err
+			}
+
+			repositoryConfig := RepositoryConfiguration{}
+			resp, err := req.
+				SetResult(&repositoryConfig).
+				SetPathParam("repo_name", repoName).
+				Get("xray/api/v1/repos_config/{repo_name}")
+			if err != nil {
+				if resp != nil && resp.StatusCode() != 0 && resp.StatusCode() < 500 {
+					tflog.Warn(ctx, fmt.Sprintf("Repo (%s) is either not indexed or does not exist", repoName))
+					return nil
+				}
+				return err
+			}
+
+			if isRepositoryConfigApplied(expectedConfig, repositoryConfig) {
+				mutex.Lock()
+				appliedRepos = append(appliedRepos, repoName)
+				mutex.Unlock()
+			}
+
+			return nil
+		})
+		if len(errors) > 0 {
+			return diag.Errorf("failed to read repositories config %q", errors)
+		}
+
+		sort.Strings(appliedRepos)
+		if err := d.Set("repos", appliedRepos); err != nil {
+			return diag.FromErr(err)
+		}
+
+		var repoConfig *RepoConfiguration
+		if config := d.Get("config").(*schema.Set); config.Len() > 0 {
+			repoConfig = unpackRepoConfig(config)
+		}
+		testPaths := util.CastToStringArr(d.Get("test_paths").([]interface{}))
+		if err := d.Set("effective_rules", packEffectiveRules(testPaths, repoConfig, unpackRepoPathConfig(d.Get("paths_config").(*schema.Set)))); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	// All the resolved repositories are updated, so the configuration changes and the drift are reconciled.
+	var resourceXrayRepositoriesConfigUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client := m.(ProviderMetadata).Client
+		projectKey := d.Get("project_key").(string)
+
+		repositoryConfigs, notIndexedRepoNames, err := resolveRepositoryConfigs(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		configsByName := map[string]RepositoryConfiguration{}
+		for _, repositoryConfig := range repositoryConfigs {
+			configsByName[repositoryConfig.RepoName] = repositoryConfig
+		}
+
+		repoNames := repositoryConfigNames(repositoryConfigs)
+		errors := forEachRepo(ctx, repoNames, d.Get("parallelism").(int), d.Get("rate_limit").(int), func(repoName string) error {
+			req, err := getRestyRequest(m.(ProviderMetadata), projectKey)
+			if err != nil {
+				return err
+			}
+
+			repositoryConfig := configsByName[repoName]
+			_, err = req.SetBody(&repositoryConfig).Put("xray/api/v1/repos_config")
+			return err
+		})
+		if len(errors) > 0 {
+			return diag.Errorf("failed to update repositories config %q", errors)
+		}
+
+		if err := d.Set("repos", repoNames); err != nil {
+			return diag.FromErr(err)
+		}
+
+		diags := resourceXrayRepositoriesConfigRead(ctx, d, m)
+		if len(notIndexedRepoNames) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Repositories are not indexed by Xray",
+				Detail:   fmt.Sprintf("The repositories %q match 'repo_include_pattern', but are not indexed by Xray, so they are skipped. Use the 'xray_indexed_resources' resource to index them, or exclude them with 'repo_exclude_pattern'.", notIndexedRepoNames),
+			})
+		}
+
+		return diags
+	}
+
+	var resourceXrayRepositoriesConfigCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d.SetId(resource.PrefixedUniqueId("repositories-config-"))
+
+		diags := resourceXrayRepositoriesConfigUpdate(ctx, d, m)
+		if diags.HasError() {
+			d.SetId("")
+		}
+
+		return diags
+	}
+
+	// No delete functionality provided by API, same as for 'xray_repository_config'.
+	var resourceXrayRepositoriesConfigDelete = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		tflog.Warn(ctx, fmt.Sprintf("There is no delete functionality in the API, so the configuration is not "+
+			"removed from the Artifactory, but (%s) is removed from the Terraform state", d.Id()))
+		d.SetId("")
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "No delete functionality provided by API",
+			Detail:   "Delete function will return a warning and remove the Id from the Terraform state. The actual repositories configuration will remain unchanged.",
+		}}
+	}
+
+	// The list of the repositories is resolved during the plan, so the new repositories matching the pattern
+	// and the repositories with a drifted configuration produce a diff.
+	var repositoriesConfigDiff = func(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if m == nil {
+			return nil
+		}
+
+		if !diff.NewValueKnown("repo_names") || !diff.NewValueKnown("repo_include_pattern") || !diff.NewValueKnown("repo_exclude_pattern") || !diff.NewValueKnown("package_type_config") {
+			return diff.SetNewComputed("repos")
+		}
+
+		repositoryConfigs, _, err := resolveRepositoryConfigs(m.(ProviderMetadata).Client, diff)
+		if err != nil {
+			return err
+		}
+		repoNames := repositoryConfigNames(repositoryConfigs)
+
+		currentRepoNames := util.CastToStringArr(diff.Get("repos").(*schema.Set).List())
+		sort.Strings(currentRepoNames)
+		if !reflect.DeepEqual(repoNames, currentRepoNames) {
+			return diff.SetNew("repos", repoNames)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayRepositoriesConfigCreate,
+		ReadContext:   resourceXrayRepositoriesConfigRead,
+		UpdateContext: resourceXrayRepositoriesConfigUpdate,
+		DeleteContext: resourceXrayRepositoriesConfigDelete,
+
+		CustomizeDiff: customdiff.All(
+			repoPathsConfigDiff,
+			vulnContextualAnalysisDiff("config", "package_type_config"),
+			repositoriesConfigDiff,
+		),
+
+		Schema: repositoriesConfigSchema,
+		Description: "Provides an Xray repositories config resource, which applies the same configuration to many repositories. " +
+			"The repositories are selected by name or by a regular expression, matched against the Artifactory repositories list. " +
+			"The requests to Xray are sent concurrently, limited by `parallelism` and `rate_limit`. " +
+			"See [Xray Indexing Resources](https://www.jfrog.com/confluence/display/JFROG/Indexing+Xray+Resources#IndexingXrayResources-SetaRetentionPeriod) and [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-UpdateRepositoriesConfigurations) for more details.",
+	}
+}
@@ -0,0 +1,259 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const repositoriesConfigTemplate = `
+resource "xray_repositories_config" "{{ .resource_name }}" {
+  repo_names           = ["{{ .repo_name }}"]
+  repo_include_pattern = "^{{ .repo_prefix }}-"
+  repo_exclude_pattern = "-excluded$"
+  parallelism          = 2
+  rate_limit           = 5
+
+  config {
+    retention_in_days = {{ .retention_in_days }}
+  }
+}`
+
+func TestAccRepositoriesConfig(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repos-config-", "xray_repositories_config")
+	repoPrefix := fmt.Sprintf("repos-config-%d", test.RandomInt())
+	repoName := fmt.Sprintf("repos-config-named-%d", test.RandomInt())
+	repos := []string{
+		repoName,
+		repoPrefix + "-1",
+		repoPrefix + "-2",
+		repoPrefix + "-excluded",
+	}
+
+	testData := map[string]string{
+		"resource_name":     resourceName,
+		"repo_name":         repoName,
+		"repo_prefix":       repoPrefix,
+		"retention_in_days": "60",
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["retention_in_days"] = "45"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			for _, repo := range repos {
+				testAccCreateRepos(t, repo, "local", "")
+			}
+		},
+		CheckDestroy: verifyDeleted(fqrn, func(id string, request *resty.Request) (*resty.Response, error) {
+			for _, repo := range repos {
+				testAccDeleteRepo(t, repo)
+			}
+			return dummyError(), fmt.Errorf("repos were deleted")
+		}),
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, repositoriesConfigTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "repos.#", "3"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "repos.*", repoName),
+					resource.TestCheckTypeSetElemAttr(fqrn, "repos.*", repoPrefix+"-1"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "repos.*", repoPrefix+"-2"),
+					resource.TestCheckResourceAttr(fqrn, "config.0.retention_in_days", testData["retention_in_days"]),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, repositoriesConfigTemplate, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "repos.#", "3"),
+					resource.TestCheckResourceAttr(fqrn, "config.0.retention_in_days", updatedTestData["retention_in_days"]),
+				),
+			},
+		},
+	})
+}
+
+const repositoriesConfigPackageTypeTemplate = `
+resource "xray_repositories_config" "{{ .resource_name }}" {
+  repo_include_pattern = "^{{ .repo_prefix }}-"
+
+  package_type_config {
+    package_type      = "generic"
+    retention_in_days = 30
+  }
+}`
+
+func TestAccRepositoriesConfig_packageTypeConfig(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repos-config-", "xray_repositories_config")
+	repoPrefix := fmt.Sprintf("repos-config-%d", test.RandomInt())
+	repos := []string{repoPrefix + "-1", repoPrefix + "-2"}
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"repo_prefix":   repoPrefix,
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			for _, repo := range repos {
+				testAccCreateRepos(t, repo, "local", "")
+			}
+		},
+		CheckDestroy: verifyDeleted(fqrn, func(id string, request *resty.Request) (*resty.Response, error) {
+			for _, repo := range repos {
+				testAccDeleteRepo(t, repo)
+			}
+			return dummyError(), fmt.Errorf("repos were deleted")
+		}),
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, repositoriesConfigPackageTypeTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "repos.#", "2"),
+					resource.TestCheckResourceAttr(fqrn, "package_type_config.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestForEachRepo(t *testing.T) {
+	var calls, running, maxRunning int32
+
+	errors := forEachRepo(context.Background(), []string{"repo-1", "repo-2", "repo-3", "repo-4", "repo-5"}, 2, 100, func(repoName string) error {
+		atomic.AddInt32(&calls, 1)
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+
+		if repoName == "repo-3" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+
+	if calls != 5 {
+		t.Errorf("expected 5 calls, got %d", calls)
+	}
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 concurrent calls, got %d", maxRunning)
+	}
+	if len(errors) != 1 || errors[0].Error() != "repo (repo-3): failed" {
+		t.Errorf("expected a single error for repo-3, got %v", errors)
+	}
+}
+
+func TestForEachRepo_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+
+	done := make(chan []error)
+	go func() {
+		done <- forEachRepo(ctx, []string{"repo-1", "repo-2", "repo-3"}, 1, 100, func(repoName string) error {
+			<-release
+			return nil
+		})
+	}()
+
+	// The first call holds the only slot, the second one waits for it until the context is cancelled
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+		t.Fatal("expected forEachRepo to wait for the running call")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	errors := <-done
+	if len(errors) != 1 || errors[0] != context.Canceled {
+		t.Errorf("expected the context error, got %v", errors)
+	}
+}
+
+func TestIsRepositoryConfigApplied(t *testing.T) {
+	expected := RepositoryConfiguration{
+		RepoName: "repo",
+		RepoPathsConfig: &PathsConfiguration{
+			Patterns: []Pattern{
+				{Include: "core/**", IndexNewArtifacts: true, RetentionInDays: 30},
+				{Include: "libs/**", Exclude: "libs/tmp/**", IndexNewArtifacts: true, RetentionInDays: 60},
+			},
+			OtherArtifacts: AllOtherArtifacts{IndexNewArtifacts: true, RetentionInDays: 90},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		actual   RepositoryConfiguration
+		expected bool
+	}{
+		{
+			"defaults added by Xray and different order",
+			RepositoryConfiguration{
+				RepoName:   "repo",
+				RepoConfig: &RepoConfiguration{RetentionInDays: 90},
+				RepoPathsConfig: &PathsConfiguration{
+					Patterns: []Pattern{
+						{Include: "libs/**", Exclude: "libs/tmp/**", IndexNewArtifacts: true, RetentionInDays: 60},
+						{Include: "core/**", Exclude: "core/tmp/**", IndexNewArtifacts: true, RetentionInDays: 30},
+					},
+					OtherArtifacts: AllOtherArtifacts{IndexNewArtifacts: true, RetentionInDays: 90},
+				},
+			},
+			true,
+		},
+		{
+			"changed retention",
+			RepositoryConfiguration{
+				RepoName: "repo",
+				RepoPathsConfig: &PathsConfiguration{
+					Patterns: []Pattern{
+						{Include: "core/**", IndexNewArtifacts: true, RetentionInDays: 30},
+						{Include: "libs/**", Exclude: "libs/tmp/**", IndexNewArtifacts: true, RetentionInDays: 10},
+					},
+					OtherArtifacts: AllOtherArtifacts{IndexNewArtifacts: true, RetentionInDays: 90},
+				},
+			},
+			false,
+		},
+		{
+			"missing paths config",
+			RepositoryConfiguration{RepoName: "repo", RepoConfig: &RepoConfiguration{RetentionInDays: 90}},
+			false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		if actual := isRepositoryConfigApplied(expected, testCase.actual); actual != testCase.expected {
+			t.Errorf("%s: expected %t, got %t", testCase.name, testCase.expected, actual)
+		}
+	}
+
+	if !isRepositoryConfigApplied(
+		RepositoryConfiguration{RepoConfig: &RepoConfiguration{RetentionInDays: 30}},
+		RepositoryConfiguration{RepoConfig: &RepoConfiguration{RetentionInDays: 30, VulnContextualAnalysis: true}},
+	) {
+		t.Error("expected 'vuln_contextual_analysis' added by Xray to be ignored")
+	}
+}
@@ -2,12 +2,14 @@ package xray
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jfrog/terraform-provider-shared/util"
 	"github.com/jfrog/terraform-provider-shared/validator"
@@ -43,235 +45,513 @@ type RepositoryConfiguration struct {
 	RepoPathsConfig *PathsConfiguration `json:"repo_paths_config,omitempty"`
 }
 
-func resourceXrayRepositoryConfig() *schema.Resource {
-	var repositoryConfigSchema = util.MergeMaps(
-		map[string]*schema.Schema{
-			"repo_name": {
-				Type:             schema.TypeString,
-				Required:         true,
-				Description:      `Repository name.`,
-				ValidateDiagFunc: validator.StringIsNotEmpty,
+var repoConfigBlocksSchema = map[string]*schema.Schema{
+	"config": {
+		Type:          schema.TypeSet,
+		Optional:      true,
+		MaxItems:      1,
+		Description:   `Single repository configuration. Only one of 'config' or 'paths_config' can be set.`,
+		ConflictsWith: []string{"paths_config"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"vuln_contextual_analysis": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: `Only for SaaS instances, will be available after Xray 3.59. Enables vulnerability contextual analysis.`,
+				},
+				"retention_in_days": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          90,
+					Description:      `The artifact will be retained for the number of days you set here, after the artifact is scanned. This will apply to all artifacts in the repository.`,
+					ValidateDiagFunc: validator.IntAtLeast(0),
+				},
 			},
-			"config": {
-				Type:          schema.TypeSet,
-				Optional:      true,
-				MaxItems:      1,
-				Description:   `Single repository configuration. Only one of 'config' or 'paths_config' can be set.`,
-				ConflictsWith: []string{"paths_config"},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"vuln_contextual_analysis": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Description: `Only for SaaS instances, will be available after Xray 3.59. Enables vulnerability contextual analysis.`,
-						},
-						"retention_in_days": {
-							Type:             schema.TypeInt,
-							Optional:         true,
-							Default:          90,
-							Description:      `The artifact will be retained for the number of days you set here, after the artifact is scanned. This will apply to all artifacts in the repository.`,
-							ValidateDiagFunc: validator.IntAtLeast(0),
+		},
+	},
+	"paths_config": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		MaxItems:    1,
+		Description: `Enables you to set a more granular retention period. It enables you to scan future artifacts within the specific path, and set a retention period for the historical data of artifacts after they are scanned`,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pattern": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Description: `Pattern, applied to the repositories.`,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"include": {
+								Type:             schema.TypeString,
+								Required:         true,
+								Description:      "Include pattern, in Ant-style format, e.g. `core/**`. Patterns are evaluated in order, so a pattern must not be shadowed by an earlier pattern without `exclude`.",
+								ValidateDiagFunc: antPattern,
+							},
+							"exclude": {
+								Type:             schema.TypeString,
+								Optional:         true,
+								Description:      "Exclude pattern, in Ant-style format, e.g. `core/internal/**`.",
+								ValidateDiagFunc: antPattern,
+							},
+							"index_new_artifacts": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     true,
+								Description: `If checked, Xray will scan newly added artifacts in the path. Note that existing artifacts will not be scanned. If the folder contains existing artifacts that have been scanned, and you do not want to index new artifacts in that folder, you can choose not to index that folder.`,
+							},
+							"retention_in_days": {
+								Type:             schema.TypeInt,
+								Optional:         true,
+								Default:          90,
+								Description:      `The artifact will be retained for the number of days you set here, after the artifact is scanned. This will apply to all artifacts in the repository.`,
+								ValidateDiagFunc: validator.IntAtLeast(0),
+							},
 						},
 					},
 				},
-			},
-			"paths_config": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				MaxItems:    1,
-				Description: `Enables you to set a more granular retention period. It enables you to scan future artifacts within the specific path, and set a retention period for the historical data of artifacts after they are scanned`,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"pattern": {
-							Type:        schema.TypeList,
-							Required:    true,
-							MinItems:    1,
-							Description: `Pattern, applied to the repositories.`,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"include": {
-										Type:             schema.TypeString,
-										Required:         true,
-										Description:      `Include pattern.`,
-										ValidateDiagFunc: validator.StringIsNotEmpty,
-									},
-									"exclude": {
-										Type:             schema.TypeString,
-										Optional:         true,
-										Description:      `Exclude pattern.`,
-										ValidateDiagFunc: validator.StringIsNotEmpty,
-									},
-									"index_new_artifacts": {
-										Type:        schema.TypeBool,
-										Optional:    true,
-										Default:     true,
-										Description: `If checked, Xray will scan newly added artifacts in the path. Note that existing artifacts will not be scanned. If the folder contains existing artifacts that have been scanned, and you do not want to index new artifacts in that folder, you can choose not to index that folder.`,
-									},
-									"retention_in_days": {
-										Type:             schema.TypeInt,
-										Optional:         true,
-										Default:          90,
-										Description:      `The artifact will be retained for the number of days you set here, after the artifact is scanned. This will apply to all artifacts in the repository.`,
-										ValidateDiagFunc: validator.IntAtLeast(0),
-									},
-								},
+				"all_other_artifacts": {
+					Type:        schema.TypeSet,
+					Required:    true,
+					Description: `If you select by pattern, you must define a retention period for all other artifacts in the repository in the All Other Artifacts setting.`,
+					MinItems:    1,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"index_new_artifacts": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     true,
+								Description: `If checked, Xray will scan newly added artifacts in the path. Note that existing artifacts will not be scanned. If the folder contains existing artifacts that have been scanned, and you do not want to index new artifacts in that folder, you can choose not to index that folder.`,
 							},
-						},
-						"all_other_artifacts": {
-							Type:        schema.TypeSet,
-							Required:    true,
-							Description: `If you select by pattern, you must define a retention period for all other artifacts in the repository in the All Other Artifacts setting.`,
-							MinItems:    1,
-							MaxItems:    1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"index_new_artifacts": {
-										Type:        schema.TypeBool,
-										Optional:    true,
-										Default:     true,
-										Description: `If checked, Xray will scan newly added artifacts in the path. Note that existing artifacts will not be scanned. If the folder contains existing artifacts that have been scanned, and you do not want to index new artifacts in that folder, you can choose not to index that folder.`,
-									},
-									"retention_in_days": {
-										Type:             schema.TypeInt,
-										Optional:         true,
-										Default:          90,
-										Description:      `The artifact will be retained for the number of days you set here, after the artifact is scanned. This will apply to all artifacts in the repository.`,
-										ValidateDiagFunc: validator.IntAtLeast(0),
-									},
-								},
+							"retention_in_days": {
+								Type:             schema.TypeInt,
+								Optional:         true,
+								Default:          90,
+								Description:      `The artifact will be retained for the number of days you set here, after the artifact is scanned. This will apply to all artifacts in the repository.`,
+								ValidateDiagFunc: validator.IntAtLeast(0),
 							},
 						},
 					},
 				},
 			},
 		},
-	)
+	},
+	"test_paths": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Sample artifact paths, relative to the repository root, e.g. `core/lib/app.jar`. For each path the rule applied by `config` or `paths_config` is shown in `effective_rules` during the plan.",
+		Elem: &schema.Schema{
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+		},
+	},
+	"effective_rules": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Rules applied to the paths from `test_paths`, in the same order.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"path": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Path from `test_paths`.",
+				},
+				"rule": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Rule applied to the path: `config`, `paths_config.pattern.<index>` or `paths_config.all_other_artifacts`.",
+				},
+				"index_new_artifacts": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether new artifacts in the path are indexed.",
+				},
+				"retention_in_days": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Retention period of the artifacts in the path.",
+				},
+			},
+		},
+	},
+}
 
-	var unpackPattern = func(s []interface{}) []Pattern {
-		var patterns []Pattern
+func unpackPattern(s []interface{}) []Pattern {
+	var patterns []Pattern
 
-		for _, raw := range s {
-			data := raw.(map[string]interface{})
-			pattern := Pattern{
-				Include:           data["include"].(string),
-				Exclude:           data["exclude"].(string),
-				IndexNewArtifacts: data["index_new_artifacts"].(bool),
-				RetentionInDays:   data["retention_in_days"].(int),
-			}
-			patterns = append(patterns, pattern)
+	for _, raw := range s {
+		data := raw.(map[string]interface{})
+		pattern := Pattern{
+			Include:           data["include"].(string),
+			Exclude:           data["exclude"].(string),
+			IndexNewArtifacts: data["index_new_artifacts"].(bool),
+			RetentionInDays:   data["retention_in_days"].(int),
 		}
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns
+}
 
-		return patterns
+func unpackAllOtherArtifacts(config *schema.Set) AllOtherArtifacts {
+	allOtherArtifacts := AllOtherArtifacts{}
+
+	if config != nil {
+		data := config.List()[0].(map[string]interface{})
+		allOtherArtifacts.IndexNewArtifacts = data["index_new_artifacts"].(bool)
+		allOtherArtifacts.RetentionInDays = data["retention_in_days"].(int)
 	}
 
-	var unpackAllOtherArtifacts = func(config *schema.Set) AllOtherArtifacts {
-		allOtherArtifacts := AllOtherArtifacts{}
+	return allOtherArtifacts
+}
 
-		if config != nil {
-			data := config.List()[0].(map[string]interface{})
-			allOtherArtifacts.IndexNewArtifacts = data["index_new_artifacts"].(bool)
-			allOtherArtifacts.RetentionInDays = data["retention_in_days"].(int)
-		}
+func unpackRepoPathConfig(config *schema.Set) *PathsConfiguration {
+	repoPathsConfiguration := new(PathsConfiguration)
+	configList := config.List()
+	if len(configList) == 0 {
+		return nil
+	}
+
+	m := configList[0].(map[string]interface{})
+
+	otherArtifacts := unpackAllOtherArtifacts(m["all_other_artifacts"].(*schema.Set))
+	repoPathsConfiguration.OtherArtifacts = otherArtifacts
+
+	repoPathsConfiguration.Patterns = unpackPattern(m["pattern"].([]interface{}))
+
+	return repoPathsConfiguration
+}
+
+func unpackRepoConfig(config *schema.Set) *RepoConfiguration {
+	repoConfig := new(RepoConfiguration)
 
-		return allOtherArtifacts
+	if config != nil {
+		data := config.List()[0].(map[string]interface{})
+		repoConfig.VulnContextualAnalysis = data["vuln_contextual_analysis"].(bool)
+		repoConfig.RetentionInDays = data["retention_in_days"].(int)
 	}
 
-	var unpackRepoPathConfig = func(config *schema.Set) *PathsConfiguration {
-		repoPathsConfiguration := new(PathsConfiguration)
-		configList := config.List()
-		if len(configList) == 0 {
-			return nil
-		}
+	return repoConfig
+}
 
-		m := configList[0].(map[string]interface{})
+func packGeneralRepoConfig(repoConfig *RepoConfiguration) []interface{} {
+	if repoConfig == nil {
+		return []interface{}{}
+	}
 
-		otherArtifacts := unpackAllOtherArtifacts(m["all_other_artifacts"].(*schema.Set))
-		repoPathsConfiguration.OtherArtifacts = otherArtifacts
+	m := map[string]interface{}{
+		"vuln_contextual_analysis": repoConfig.VulnContextualAnalysis,
+		"retention_in_days":        repoConfig.RetentionInDays,
+	}
 
-		repoPathsConfiguration.Patterns = unpackPattern(m["pattern"].([]interface{}))
+	return []interface{}{m}
+}
 
-		return repoPathsConfiguration
+func packAllOtherArtifacts(otherArtifacts AllOtherArtifacts) []interface{} {
+	m := map[string]interface{}{
+		"index_new_artifacts": otherArtifacts.IndexNewArtifacts,
+		"retention_in_days":   otherArtifacts.RetentionInDays,
 	}
 
-	var unpackRepoConfig = func(config *schema.Set) *RepoConfiguration {
-		repoConfig := new(RepoConfiguration)
+	return []interface{}{m}
+}
+
+func packPatterns(patterns []Pattern) []interface{} {
+	var ps []interface{}
 
-		if config != nil {
-			data := config.List()[0].(map[string]interface{})
-			repoConfig.VulnContextualAnalysis = data["vuln_contextual_analysis"].(bool)
-			repoConfig.RetentionInDays = data["retention_in_days"].(int)
+	for _, pattern := range patterns {
+		p := map[string]interface{}{
+			"include":             pattern.Include,
+			"exclude":             pattern.Exclude,
+			"index_new_artifacts": pattern.IndexNewArtifacts,
+			"retention_in_days":   pattern.RetentionInDays,
 		}
 
-		return repoConfig
+		ps = append(ps, p)
 	}
 
-	var unpackRepositoryConfig = func(s *schema.ResourceData) RepositoryConfiguration {
-		d := &util.ResourceData{ResourceData: s}
+	return ps
+}
 
-		repositoryConfig := RepositoryConfiguration{
-			RepoName: d.GetString("repo_name", false),
+func packRepoPathsConfigList(repoPathsConfig *PathsConfiguration) []interface{} {
+	if repoPathsConfig == nil {
+		return []interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"pattern":             packPatterns(repoPathsConfig.Patterns),
+		"all_other_artifacts": packAllOtherArtifacts(repoPathsConfig.OtherArtifacts),
+	}
+
+	return []interface{}{m}
+}
+
+// validatePatterns returns an error, if a pattern can't match any path, because all the paths matched by it
+// are already matched by an earlier pattern without exclude.
+func validatePatterns(patterns []Pattern) error {
+	for j, pattern := range patterns {
+		for i, earlierPattern := range patterns[:j] {
+			if len(earlierPattern.Exclude) == 0 && antPatternCovers(earlierPattern.Include, pattern.Include) {
+				return fmt.Errorf("paths_config pattern %d with include '%s' is unreachable, because it is shadowed by pattern %d with include '%s'", j, pattern.Include, i, earlierPattern.Include)
+			}
 		}
+	}
 
-		if _, ok := s.GetOk("config"); ok {
-			repositoryConfig.RepoConfig = unpackRepoConfig(s.Get("config").(*schema.Set))
+	return nil
+}
+
+func packEffectiveRules(testPaths []string, repoConfig *RepoConfiguration, repoPathsConfig *PathsConfiguration) []interface{} {
+	rules := []interface{}{}
+
+	for _, path := range testPaths {
+		rule := map[string]interface{}{
+			"path": path,
 		}
 
-		if _, ok := s.GetOk("paths_config"); ok {
-			repositoryConfig.RepoPathsConfig = unpackRepoPathConfig(s.Get("paths_config").(*schema.Set))
+		switch {
+		case repoPathsConfig != nil:
+			rule["rule"] = "paths_config.all_other_artifacts"
+			rule["index_new_artifacts"] = repoPathsConfig.OtherArtifacts.IndexNewArtifacts
+			rule["retention_in_days"] = repoPathsConfig.OtherArtifacts.RetentionInDays
+
+			for i, pattern := range repoPathsConfig.Patterns {
+				if antPatternMatch(pattern.Include, path) && (len(pattern.Exclude) == 0 || !antPatternMatch(pattern.Exclude, path)) {
+					rule["rule"] = fmt.Sprintf("paths_config.pattern.%d", i)
+					rule["index_new_artifacts"] = pattern.IndexNewArtifacts
+					rule["retention_in_days"] = pattern.RetentionInDays
+					break
+				}
+			}
+		case repoConfig != nil:
+			rule["rule"] = "config"
+			rule["index_new_artifacts"] = true
+			rule["retention_in_days"] = repoConfig.RetentionInDays
 		}
-		return repositoryConfig
+
+		rules = append(rules, rule)
 	}
 
-	var packGeneralRepoConfig = func(repoConfig *RepoConfiguration) []interface{} {
-		if repoConfig == nil {
-			return []interface{}{}
+	return rules
+}
+
+// repoPathsConfigDiff validates the 'paths_config' patterns and calculates 'effective_rules' during the plan.
+func repoPathsConfigDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("config") || !diff.NewValueKnown("paths_config") || !diff.NewValueKnown("test_paths") {
+		return diff.SetNewComputed("effective_rules")
+	}
+
+	var repoConfig *RepoConfiguration
+	if config := diff.Get("config").(*schema.Set); config.Len() > 0 {
+		repoConfig = unpackRepoConfig(config)
+	}
+	repoPathsConfig := unpackRepoPathConfig(diff.Get("paths_config").(*schema.Set))
+
+	if repoPathsConfig != nil {
+		if err := validatePatterns(repoPathsConfig.Patterns); err != nil {
+			return err
+		}
+	}
+
+	testPaths := util.CastToStringArr(diff.Get("test_paths").([]interface{}))
+	return diff.SetNew("effective_rules", packEffectiveRules(testPaths, repoConfig, repoPathsConfig))
+}
+
+var packageTypeConfigElem = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"package_type": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Package type of the Artifactory repositories, e.g. `docker` or `maven`. Case insensitive.",
+		},
+		"vuln_contextual_analysis": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: `Only for SaaS instances, will be available after Xray 3.59. Enables vulnerability contextual analysis.`,
+		},
+		"retention_in_days": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          90,
+			Description:      `The artifact will be retained for the number of days you set here, after the artifact is scanned. This will apply to all artifacts in the repository.`,
+			ValidateDiagFunc: validator.IntAtLeast(0),
+		},
+	},
+}
+
+// unpackPackageTypeConfigs returns the configurations by the lower case package type.
+// The package types, which differ only in case, are rejected.
+func unpackPackageTypeConfigs(packageTypeConfigs *schema.Set) (map[string]*RepoConfiguration, error) {
+	configs := map[string]*RepoConfiguration{}
+
+	for _, raw := range packageTypeConfigs.List() {
+		data := raw.(map[string]interface{})
+		packageType := strings.ToLower(data["package_type"].(string))
+		if _, ok := configs[packageType]; ok {
+			return nil, fmt.Errorf("package type '%s' is set in more than one 'package_type_config' block, the package types are case insensitive", packageType)
 		}
 
-		m := map[string]interface{}{
-			"vuln_contextual_analysis": repoConfig.VulnContextualAnalysis,
-			"retention_in_days":        repoConfig.RetentionInDays,
+		configs[packageType] = &RepoConfiguration{
+			VulnContextualAnalysis: data["vuln_contextual_analysis"].(bool),
+			RetentionInDays:        data["retention_in_days"].(int),
 		}
+	}
+
+	return configs, nil
+}
 
-		return []interface{}{m}
+// repositoryConfigPackageTypeDiff resolves 'config' from 'package_type_config' by the package type of the repository,
+// if neither 'config' nor 'paths_config' is set.
+func repositoryConfigPackageTypeDiff(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	packageTypeConfigs, err := unpackPackageTypeConfigs(diff.Get("package_type_config").(*schema.Set))
+	if err != nil {
+		return err
 	}
 
-	var packAllOtherArtifacts = func(otherArtifacts AllOtherArtifacts) []interface{} {
-		m := map[string]interface{}{
-			"index_new_artifacts": otherArtifacts.IndexNewArtifacts,
-			"retention_in_days":   otherArtifacts.RetentionInDays,
+	rawConfig := diff.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	if config := rawConfig.GetAttr("config"); !config.IsNull() && (!config.IsKnown() || config.LengthInt() > 0) {
+		return nil
+	}
+
+	// 'config' is computed, so it must be cleared explicitly, when it's removed from the configuration
+	if paths := rawConfig.GetAttr("paths_config"); len(packageTypeConfigs) == 0 || (!paths.IsNull() && (!paths.IsKnown() || paths.LengthInt() > 0)) {
+		if diff.Get("config").(*schema.Set).Len() > 0 {
+			return diff.SetNew("config", []interface{}{})
 		}
+		return nil
+	}
+
+	if m == nil {
+		return nil
+	}
+	if !diff.NewValueKnown("repo_name") {
+		return diff.SetNewComputed("config")
+	}
 
-		return []interface{}{m}
+	meta := m.(ProviderMetadata)
+	repoName := diff.Get("repo_name").(string)
+	repo, err := cachedLookup(meta.Cache, "artifactory_repository/"+repoName, func() (ArtifactoryRepository, error) {
+		return getArtifactoryRepository(meta.Client, repoName)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the package type of repo (%s): %w", repoName, err)
 	}
 
-	var packPatterns = func(patterns []Pattern) []interface{} {
-		var ps []interface{}
+	packageTypeConfig, ok := packageTypeConfigs[strings.ToLower(repo.PackageType)]
+	if !ok {
+		return fmt.Errorf("none of 'config', 'paths_config' or 'package_type_config' matching the package type '%s' of repo (%s) is set", repo.PackageType, repoName)
+	}
 
-		for _, pattern := range patterns {
-			p := map[string]interface{}{
-				"include":             pattern.Include,
-				"exclude":             pattern.Exclude,
-				"index_new_artifacts": pattern.IndexNewArtifacts,
-				"retention_in_days":   pattern.RetentionInDays,
-			}
+	return diff.SetNew("config", packGeneralRepoConfig(packageTypeConfig))
+}
 
-			ps = append(ps, p)
+// vulnContextualAnalysisDiff fails the plan, if 'vuln_contextual_analysis' is enabled in any of the blocks
+// and the Xray instance doesn't support it or isn't entitled to it.
+func vulnContextualAnalysisDiff(blocks ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if m == nil {
+			return nil
+		}
+
+		for _, block := range blocks {
+			for _, config := range diff.Get(block).(*schema.Set).List() {
+				if config.(map[string]interface{})["vuln_contextual_analysis"].(bool) {
+					meta := m.(ProviderMetadata)
+					attribute := fmt.Sprintf("'%s.vuln_contextual_analysis'", block)
+					if err := meta.checkCapability(ctx, capabilityVulnContextualAnalysis, attribute); err != nil {
+						return err
+					}
+					return meta.checkEntitlement(ctx, advancedSecurityFeatureId, "JFrog Advanced Security", attribute)
+				}
+			}
 		}
 
-		return ps
+		return nil
 	}
+}
+
+func resourceXrayRepositoryConfig() *schema.Resource {
+	// 'config' is computed, when it's resolved from 'package_type_config'
+	repositoryConfigBlock := *repoConfigBlocksSchema["config"]
+	repositoryConfigBlock.Computed = true
 
-	var packRepoPathsConfigList = func(repoPathsConfig *PathsConfiguration) []interface{} {
-		if repoPathsConfig == nil {
-			return []interface{}{}
+	var repositoryConfigSchema = util.MergeMaps(
+		map[string]*schema.Schema{
+			"repo_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      `Repository name.`,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+			},
+			"restore_on_destroy": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "If set to `true`, the repository configuration, which existed before the resource was created, is restored when the resource is destroyed. The configuration is captured in the `original_config` attribute. If set to `true` on an existing resource, the configuration captured is the one managed by the resource at that time. Only one of `restore_on_destroy` or `default_config` can be set. Default value is `false`.",
+				ConflictsWith: []string{"default_config"},
+			},
+			"default_config": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Repository configuration, which is applied when the resource is destroyed. Only one of `restore_on_destroy` or `default_config` can be set.",
+				ConflictsWith: []string{"restore_on_destroy"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vuln_contextual_analysis": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: `Only for SaaS instances, will be available after Xray 3.59. Enables vulnerability contextual analysis.`,
+						},
+						"retention_in_days": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          90,
+							Description:      `The artifact will be retained for the number of days you set here, after the artifact is scanned. This will apply to all artifacts in the repository.`,
+							ValidateDiagFunc: validator.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"original_config": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON representation of the repository configuration, which existed before the resource was created. Used by `restore_on_destroy`.",
+			},
+		},
+		repoConfigBlocksSchema,
+		map[string]*schema.Schema{
+			"config": &repositoryConfigBlock,
+			"package_type_config": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Configuration applied, if neither `config` nor `paths_config` is set, selected by the package type of the repository. Allows to share the defaults per package type between the resources, e.g. 30 days retention for Docker and 365 days for Maven repositories. The resolved configuration is shown in `config`.",
+				Elem:        packageTypeConfigElem,
+			},
+		},
+		getProjectKeySchema(false, "The repository must be assigned to the project."),
+	)
+
+	var unpackRepositoryConfig = func(s *schema.ResourceData) RepositoryConfiguration {
+		d := &util.ResourceData{ResourceData: s}
+
+		repositoryConfig := RepositoryConfiguration{
+			RepoName: d.GetString("repo_name", false),
 		}
 
-		m := map[string]interface{}{
-			"pattern":             packPatterns(repoPathsConfig.Patterns),
-			"all_other_artifacts": packAllOtherArtifacts(repoPathsConfig.OtherArtifacts),
+		if _, ok := s.GetOk("config"); ok {
+			repositoryConfig.RepoConfig = unpackRepoConfig(s.Get("config").(*schema.Set))
 		}
 
-		return []interface{}{m}
+		if _, ok := s.GetOk("paths_config"); ok {
+			repositoryConfig.RepoPathsConfig = unpackRepoPathConfig(s.Get("paths_config").(*schema.Set))
+		}
+		return repositoryConfig
 	}
 
 	var packRepositoryConfig = func(ctx context.Context, repositoryConfig RepositoryConfiguration, d *schema.ResourceData) diag.Diagnostics {
@@ -284,6 +564,11 @@ func resourceXrayRepositoryConfig() *schema.Resource {
 		if err := d.Set("paths_config", packRepoPathsConfigList(repositoryConfig.RepoPathsConfig)); err != nil {
 			return diag.FromErr(err)
 		}
+		// Set on read as well, so 'effective_rules' is available after import
+		testPaths := util.CastToStringArr(d.Get("test_paths").([]interface{}))
+		if err := d.Set("effective_rules", packEffectiveRules(testPaths, repositoryConfig.RepoConfig, repositoryConfig.RepoPathsConfig)); err != nil {
+			return diag.FromErr(err)
+		}
 
 		return nil
 	}
@@ -291,7 +576,12 @@ func resourceXrayRepositoryConfig() *schema.Resource {
 	var resourceXrayRepositoryConfigRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		repositoryConfig := RepositoryConfiguration{}
 
-		resp, err := m.(*resty.Client).R().
+		req, err := getRestyRequest(m.(ProviderMetadata), d.Get("project_key").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		resp, err := req.
 			SetResult(&repositoryConfig).
 			SetPathParam("repo_name", d.Id()).
 			Get("xray/api/v1/repos_config/{repo_name}")
@@ -307,10 +597,54 @@ func resourceXrayRepositoryConfig() *schema.Resource {
 		return packRepositoryConfig(ctx, repositoryConfig, d)
 	}
 
+	// captureOriginalConfig saves the actual configuration, which existed before the resource was created or updated, to be able to restore it on destroy.
+	// The configuration can't be captured, if the repository is not indexed yet, in this case the attribute remains empty.
+	var captureOriginalConfig = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		originalConfig := RepositoryConfiguration{}
+
+		req, err := getRestyRequest(m.(ProviderMetadata), d.Get("project_key").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = req.
+			SetResult(&originalConfig).
+			SetPathParam("repo_name", d.Get("repo_name").(string)).
+			Get("xray/api/v1/repos_config/{repo_name}")
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Unable to capture the original configuration of repo (%s): %s", d.Get("repo_name"), err))
+			return nil
+		}
+
+		originalConfigJson, err := json.Marshal(originalConfig)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("original_config", string(originalConfigJson)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
 	var resourceXrayRepositoryConfigCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		repositoryConfig := unpackRepositoryConfig(d)
 
-		_, err := m.(*resty.Client).R().SetBody(&repositoryConfig).Put("xray/api/v1/repos_config")
+		// The original configuration is only needed to restore it on destroy. If 'restore_on_destroy' is enabled
+		// on an existing resource, the configuration managed so far is captured instead.
+		if d.Get("restore_on_destroy").(bool) && (d.IsNewResource() || d.HasChange("restore_on_destroy")) {
+			if diags := captureOriginalConfig(ctx, d, m); diags.HasError() {
+				return diags
+			}
+		}
+
+		req, err := getRestyRequest(m.(ProviderMetadata), d.Get("project_key").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = req.SetBody(&repositoryConfig).Put("xray/api/v1/repos_config")
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -319,10 +653,64 @@ func resourceXrayRepositoryConfig() *schema.Resource {
 		return resourceXrayRepositoryConfigRead(ctx, d, m)
 	}
 
+	var restoreRepositoryConfig = func(ctx context.Context, repositoryConfig RepositoryConfiguration, projectKey string, m interface{}) diag.Diagnostics {
+		req, err := getRestyRequest(m.(ProviderMetadata), projectKey)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = req.SetBody(&repositoryConfig).Put("xray/api/v1/repos_config")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Configuration of repo (%s) is restored", repositoryConfig.RepoName))
+		return nil
+	}
+
 	// No delete functionality provided by API.
-	// Delete function will return a warning and remove the Id from the state.
-	// The option with restoring a default configuration is not viable, because the default can be changed.
+	// If 'default_config' or 'restore_on_destroy' is set, the corresponding configuration is applied instead.
+	// Otherwise, delete function will return a warning and remove the Id from the state.
 	var resourceXrayRepositoryConfigDelete = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if v, ok := d.GetOk("default_config"); ok {
+			diags := restoreRepositoryConfig(ctx, RepositoryConfiguration{
+				RepoName:   d.Id(),
+				RepoConfig: unpackRepoConfig(v.(*schema.Set)),
+			}, d.Get("project_key").(string), m)
+			if diags.HasError() {
+				return diags
+			}
+
+			d.SetId("")
+			return nil
+		}
+
+		if d.Get("restore_on_destroy").(bool) {
+			originalConfigJson := d.Get("original_config").(string)
+			if len(originalConfigJson) == 0 {
+				d.SetId("")
+				return diag.Diagnostics{{
+					Severity: diag.Warning,
+					Summary:  "Original repository configuration is unknown",
+					Detail:   fmt.Sprintf("The configuration of repo (%s) could not be captured when the resource was created, so it can't be restored. The actual repository configuration will remain unchanged.", d.Id()),
+				}}
+			}
+
+			originalConfig := RepositoryConfiguration{}
+			if err := json.Unmarshal([]byte(originalConfigJson), &originalConfig); err != nil {
+				return diag.FromErr(err)
+			}
+			originalConfig.RepoName = d.Id()
+
+			diags := restoreRepositoryConfig(ctx, originalConfig, d.Get("project_key").(string), m)
+			if diags.HasError() {
+				return diags
+			}
+
+			d.SetId("")
+			return nil
+		}
+
 		tflog.Warn(ctx, fmt.Sprintf("There is no delete dunctionality in the API, so the configuration is not "+
 			"removed from the Artifactory, but (%s) is removed from the Terraform state", d.Id()))
 		d.SetId("")
@@ -344,6 +732,12 @@ func resourceXrayRepositoryConfig() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: customdiff.All(
+			repositoryConfigPackageTypeDiff,
+			repoPathsConfigDiff,
+			vulnContextualAnalysisDiff("config", "default_config"),
+		),
+
 		Schema:      repositoryConfigSchema,
 		Description: "Provides an Xray repository config resource. See [Xray Indexing Resources](https://www.jfrog.com/confluence/display/JFROG/Indexing+Xray+Resources#IndexingXrayResources-SetaRetentionPeriod) and [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-UpdateRepositoriesConfigurations) for more details.",
 	}
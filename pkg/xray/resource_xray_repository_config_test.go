@@ -174,6 +174,276 @@ func TestAccRepositoryConfigRepoPathsUpdate(t *testing.T) {
 	})
 }
 
+func TestAccRepositoryConfigWithProjectKey(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repo-config-", "xray_repository_config")
+	projectKey := fmt.Sprintf("testproj%d", test.RandSelect(1, 2, 3, 4, 5))
+	var testData = map[string]string{
+		"resource_name":     resourceName,
+		"repo_name":         fmt.Sprintf("%s-repo-config-test-repo", projectKey),
+		"project_key":       projectKey,
+		"retention_in_days": "90",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			CreateProject(t, projectKey)
+			testAccCreateRepos(t, testData["repo_name"], "local", projectKey)
+		},
+		CheckDestroy: verifyDeleted(fqrn, func(id string, request *resty.Request) (*resty.Response, error) {
+			testAccDeleteRepo(t, testData["repo_name"])
+			DeleteProject(t, projectKey)
+			err := fmt.Errorf("repo was deleted")
+			errorResp := dummyError()
+			return errorResp, err
+		}),
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, TestDataRepoConfigProjectKeyTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "repo_name", testData["repo_name"]),
+					resource.TestCheckResourceAttr(fqrn, "project_key", projectKey),
+					resource.TestCheckResourceAttr(fqrn, "config.0.retention_in_days", testData["retention_in_days"]),
+				),
+			},
+		},
+	})
+}
+
+const repoConfigPackageTypeTemplate = `
+resource "xray_repository_config" "{{ .resource_name }}" {
+  repo_name = "{{ .repo_name }}"
+
+  package_type_config {
+    package_type      = "Generic"
+    retention_in_days = {{ .retention_in_days }}
+  }
+
+  package_type_config {
+    package_type      = "docker"
+    retention_in_days = 30
+  }
+}`
+
+func TestAccRepositoryConfigPackageTypeConfig(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repo-config-", "xray_repository_config")
+	var testData = map[string]string{
+		"resource_name":     resourceName,
+		"repo_name":         "repo-config-test-repo",
+		"retention_in_days": "365",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDeleteRepo(t, testData["repo_name"])
+			testAccCreateRepos(t, testData["repo_name"], "local", "")
+		},
+		CheckDestroy: verifyDeleted(fqrn, func(id string, request *resty.Request) (*resty.Response, error) {
+			testAccDeleteRepo(t, testData["repo_name"])
+			err := fmt.Errorf("repo was deleted")
+			errorResp := dummyError()
+			return errorResp, err
+		}),
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				// The repositories created by the test are generic
+				Config: util.ExecuteTemplate(fqrn, repoConfigPackageTypeTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "repo_name", testData["repo_name"]),
+					resource.TestCheckResourceAttr(fqrn, "package_type_config.#", "2"),
+					resource.TestCheckResourceAttr(fqrn, "config.0.retention_in_days", testData["retention_in_days"]),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRepositoryConfigPackageTypeConfigDuplicate(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repo-config-", "xray_repository_config")
+
+	config := util.ExecuteTemplate(fqrn, `
+resource "xray_repository_config" "{{ .resource_name }}" {
+  repo_name = "repo-config-test-repo"
+
+  package_type_config {
+    package_type      = "Docker"
+    retention_in_days = 30
+  }
+
+  package_type_config {
+    package_type      = "docker"
+    retention_in_days = 60
+  }
+}`, map[string]string{"resource_name": resourceName})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`package type 'docker' is set in more than one 'package_type_config' block`),
+			},
+		},
+	})
+}
+
+func TestAccRepositoryConfigRestoreOnDestroy(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repo-config-", "xray_repository_config")
+	var testData = map[string]string{
+		"resource_name":     resourceName,
+		"repo_name":         "repo-config-test-repo",
+		"retention_in_days": "60",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDeleteRepo(t, testData["repo_name"])
+			testAccCreateRepos(t, testData["repo_name"], "local", "")
+		},
+		CheckDestroy: verifyDeleted(fqrn, func(id string, request *resty.Request) (*resty.Response, error) {
+			repositoryConfig := RepositoryConfiguration{}
+			resp, err := request.SetResult(&repositoryConfig).Get("xray/api/v1/repos_config/" + id)
+			testAccDeleteRepo(t, testData["repo_name"])
+			if err != nil {
+				return resp, err
+			}
+			if repositoryConfig.RepoConfig != nil && repositoryConfig.RepoConfig.RetentionInDays == 60 {
+				return resp, nil
+			}
+			return dummyError(), fmt.Errorf("repo config was restored")
+		}),
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, TestDataRepoConfigRestoreOnDestroyTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "config.0.retention_in_days", testData["retention_in_days"]),
+					resource.TestCheckResourceAttr(fqrn, "restore_on_destroy", "true"),
+					resource.TestCheckResourceAttrSet(fqrn, "original_config"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRepositoryConfigDefaultConfigConflict(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repo-config-", "xray_repository_config")
+	var testData = map[string]string{
+		"resource_name":     resourceName,
+		"repo_name":         "repo-config-test-repo",
+		"retention_in_days": "60",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, TestDataRepoConfigDefaultConfigConflictTemplate, testData),
+				ExpectError: regexp.MustCompile("Conflicting configuration arguments"),
+			},
+		},
+	})
+}
+
+func TestAccRepositoryConfigRepoPathsShadowedPattern(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repo-config-", "xray_repository_config")
+	var testData = map[string]string{
+		"resource_name":                resourceName,
+		"repo_name":                    "repo-config-test-repo",
+		"pattern0_include":             "core/**",
+		"pattern0_exclude":             "",
+		"pattern0_index_new_artifacts": "true",
+		"pattern0_retention_in_days":   "45",
+		"pattern1_include":             "core/lib/**",
+		"pattern1_exclude":             "core/lib/external/**",
+		"pattern1_index_new_artifacts": "true",
+		"pattern1_retention_in_days":   "45",
+		"other_index_new_artifacts":    "true",
+		"other_retention_in_days":      "60",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, TestDataRepoPathsConfigNoExcludeTemplate, testData),
+				ExpectError: regexp.MustCompile("paths_config pattern 1 with include 'core/lib/\\*\\*' is unreachable"),
+			},
+		},
+	})
+}
+
+func TestAccRepositoryConfigRepoPathsEffectiveRules(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("xray-repo-config-", "xray_repository_config")
+	var testData = map[string]string{
+		"resource_name":                resourceName,
+		"repo_name":                    "repo-config-test-repo",
+		"pattern0_include":             "core/**",
+		"pattern0_exclude":             "core/external/**",
+		"pattern0_index_new_artifacts": "true",
+		"pattern0_retention_in_days":   "45",
+		"pattern1_include":             "core/**",
+		"pattern1_exclude":             "core/internal/**",
+		"pattern1_index_new_artifacts": "false",
+		"pattern1_retention_in_days":   "30",
+		"other_index_new_artifacts":    "true",
+		"other_retention_in_days":      "60",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDeleteRepo(t, testData["repo_name"])
+			testAccCreateRepos(t, testData["repo_name"], "local", "")
+		},
+		CheckDestroy: verifyDeleted(fqrn, func(id string, request *resty.Request) (*resty.Response, error) {
+			testAccDeleteRepo(t, testData["repo_name"])
+			err := fmt.Errorf("repo was deleted")
+			errorResp := dummyError()
+			return errorResp, err
+		}),
+		ProviderFactories: testAccProviders(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, TestDataRepoPathsConfigEffectiveRulesTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.#", "3"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.0.path", "core/lib/app.jar"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.0.rule", "paths_config.pattern.0"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.0.retention_in_days", "45"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.1.path", "core/external/lib.jar"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.1.rule", "paths_config.pattern.1"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.1.retention_in_days", "30"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.2.path", "docs/readme.md"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.2.rule", "paths_config.all_other_artifacts"),
+					resource.TestCheckResourceAttr(fqrn, "effective_rules.2.retention_in_days", "60"),
+				),
+			},
+			{
+				// 'test_paths' isn't known by the import, so 'effective_rules' are calculated on the next plan
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"test_paths", "effective_rules"},
+			},
+		},
+	})
+}
+
 func verifyRepositoryConfig(fqrn string, testData map[string]string) resource.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(
 		resource.TestCheckResourceAttr(fqrn, "repo_name", testData["repo_name"]),
@@ -261,3 +531,89 @@ resource "xray_repository_config" "{{ .resource_name }}" {
     }
   }
 }`
+
+const TestDataRepoConfigRestoreOnDestroyTemplate = `
+resource "xray_repository_config" "{{ .resource_name }}" {
+  repo_name          = "{{ .repo_name }}"
+  restore_on_destroy = true
+
+  config {
+    retention_in_days = {{ .retention_in_days }}
+  }
+}`
+
+const TestDataRepoConfigDefaultConfigConflictTemplate = `
+resource "xray_repository_config" "{{ .resource_name }}" {
+  repo_name          = "{{ .repo_name }}"
+  restore_on_destroy = true
+
+  config {
+    retention_in_days = {{ .retention_in_days }}
+  }
+
+  default_config {
+    retention_in_days = 90
+  }
+}`
+
+const TestDataRepoConfigProjectKeyTemplate = `
+resource "xray_repository_config" "{{ .resource_name }}" {
+  repo_name   = "{{ .repo_name }}"
+  project_key = "{{ .project_key }}"
+
+  config {
+    retention_in_days = {{ .retention_in_days }}
+  }
+}`
+
+const TestDataRepoPathsConfigNoExcludeTemplate = `
+resource "xray_repository_config" "{{ .resource_name }}" {
+  repo_name = "{{ .repo_name }}"
+
+  paths_config {
+    pattern {
+      include             = "{{ .pattern0_include }}"
+      index_new_artifacts = {{ .pattern0_index_new_artifacts }}
+      retention_in_days   = {{ .pattern0_retention_in_days }}
+    }
+
+    pattern {
+      include             = "{{ .pattern1_include }}"
+      exclude             = "{{ .pattern1_exclude }}"
+      index_new_artifacts = {{ .pattern1_index_new_artifacts }}
+      retention_in_days   = {{ .pattern1_retention_in_days }}
+    }
+
+    all_other_artifacts {
+      index_new_artifacts = {{ .other_index_new_artifacts }}
+      retention_in_days   = {{ .other_retention_in_days }}
+    }
+  }
+}`
+
+const TestDataRepoPathsConfigEffectiveRulesTemplate = `
+resource "xray_repository_config" "{{ .resource_name }}" {
+  repo_name  = "{{ .repo_name }}"
+  test_paths = ["core/lib/app.jar", "core/external/lib.jar", "docs/readme.md"]
+
+  paths_config {
+    pattern {
+      include             = "{{ .pattern0_include }}"
+      exclude             = "{{ .pattern0_exclude }}"
+      index_new_artifacts = {{ .pattern0_index_new_artifacts }}
+      retention_in_days   = {{ .pattern0_retention_in_days }}
+    }
+
+    pattern {
+      include             = "{{ .pattern1_include }}"
+      exclude             = "{{ .pattern1_exclude }}"
+      index_new_artifacts = {{ .pattern1_index_new_artifacts }}
+      retention_in_days   = {{ .pattern1_retention_in_days }}
+    }
+
+    all_other_artifacts {
+      index_new_artifacts = {{ .other_index_new_artifacts }}
+      retention_in_days   = {{ .other_retention_in_days }}
+    }
+  }
+}`
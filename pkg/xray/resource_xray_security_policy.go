@@ -1,11 +1,30 @@
 package xray
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
+func securityPolicyVersionDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if m == nil {
+		return nil
+	}
+
+	for _, rule := range diff.Get("rule").([]interface{}) {
+		for _, criteria := range rule.(map[string]interface{})["criteria"].(*schema.Set).List() {
+			if criteria.(map[string]interface{})["fix_version_dependant"].(bool) {
+				return m.(ProviderMetadata).checkCapability(ctx, capabilityFixVersionDependant, "'fix_version_dependant'")
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceXraySecurityPolicyV2() *schema.Resource {
 	var criteriaSchema = map[string]*schema.Schema{
 		"min_severity": {
@@ -51,12 +70,14 @@ func resourceXraySecurityPolicyV2() *schema.Resource {
 		UpdateContext: resourceXrayPolicyUpdate,
 		DeleteContext: resourceXrayPolicyDelete,
 		Description: "Creates an Xray policy using V2 of the underlying APIs. Please note: " +
-			"It's only compatible with Bearer token auth method (Identity and Access => Access Tokens)",
+			"It's only compatible with Bearer token auth method (Identity and Access => Access Tokens, or `oidc_provider_name`)",
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: customdiff.All(securityPolicyVersionDiff, policyAuthDiff),
+
 		Schema: getPolicySchema(criteriaSchema, commonActionsSchema),
 	}
 }
@@ -2,15 +2,19 @@ package xray
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"net/http"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jfrog/terraform-provider-shared/util"
 )
 
+// The settings are global, so the same ID is used by every instance of the resource
+const settingsId = "xray_settings"
+
 func resourceXraySettings() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceXrayDbSyncTimeUpdate,
@@ -30,6 +34,17 @@ func resourceXraySettings() *schema.Resource {
 				Description:      "The time of the Xray DB sync daily update job. Format HH:mm",
 				ValidateDiagFunc: matchesHoursMinutesTime,
 			},
+			"reset_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to `true`, the DB sync time, which existed before the resource was created, is restored when the resource is destroyed. The time is captured in the `original_db_sync_updates_time` attribute. If set to `true` on an existing resource, the time captured is the one managed by the resource at that time. Otherwise, the setting is only removed from the Terraform state. Default value is `false`.",
+			},
+			"original_db_sync_updates_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The DB sync time, which existed before the resource was created. Used by `reset_on_destroy`.",
+			},
 		},
 	}
 }
@@ -53,32 +68,76 @@ func packDBSyncTime(dbSyncTime DbSyncDailyUpdatesTime, d *schema.ResourceData) d
 	return nil
 }
 
-func resourceXrayDbSyncTimeRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func getDBSyncTime(client *resty.Client) (DbSyncDailyUpdatesTime, *resty.Response, error) {
 	dbSyncTime := DbSyncDailyUpdatesTime{}
-	resp, err := m.(*resty.Client).R().SetResult(&dbSyncTime).Get("xray/api/v1/configuration/dbsync/time")
+	resp, err := client.R().SetResult(&dbSyncTime).Get("xray/api/v1/configuration/dbsync/time")
+	return dbSyncTime, resp, err
+}
+
+func resourceXrayDbSyncTimeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dbSyncTime, resp, err := getDBSyncTime(m.(ProviderMetadata).Client)
 	if err != nil {
-		if resp != nil && resp.StatusCode() != http.StatusOK {
-			log.Printf("Critical error. DB sync settings (%s) not found.", d.Id())
+		if resp != nil && resp.StatusCode() == http.StatusNotFound {
+			tflog.Warn(ctx, fmt.Sprintf("Xray DB sync settings (%s) not found, removing from state", d.Id()))
+			d.SetId("")
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Xray DB sync settings not found",
+				Detail:   fmt.Sprintf("The DB sync settings were not returned by Xray: %s", err),
+			}}
 		}
 		return diag.FromErr(err)
 	}
-	packDBSyncTime(dbSyncTime, d)
-	return nil
+
+	// Earlier versions of the provider used the sync time as ID, the stable ID is set for the existing and imported resources
+	d.SetId(settingsId)
+	return packDBSyncTime(dbSyncTime, d)
 }
 
 func resourceXrayDbSyncTimeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The original time is only needed to restore it on destroy. If 'reset_on_destroy' is enabled
+	// on an existing resource, the time managed so far is captured instead.
+	if d.Get("reset_on_destroy").(bool) && (d.IsNewResource() || d.HasChange("reset_on_destroy")) {
+		originalDbSyncTime, _, err := getDBSyncTime(m.(ProviderMetadata).Client)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("original_db_sync_updates_time", originalDbSyncTime.DbSyncTime); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	dbSyncTime := unpackDBSyncTime(d)
-	_, err := m.(*resty.Client).R().SetBody(dbSyncTime).Put("xray/api/v1/configuration/dbsync/time")
+	_, err := m.(ProviderMetadata).Client.R().SetBody(dbSyncTime).Put("xray/api/v1/configuration/dbsync/time")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(dbSyncTime.DbSyncTime)
+	d.SetId(settingsId)
 	return resourceXrayDbSyncTimeRead(ctx, d, m)
 }
 
 // No delete functionality provided by API for the DB sync call.
-// Delete function will remove the object from the Terraform state
-func resourceXrayDbSyncTimeDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// If 'reset_on_destroy' is set, the original DB sync time is restored, otherwise the object is only removed from the Terraform state.
+func resourceXrayDbSyncTimeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("reset_on_destroy").(bool) {
+		originalDbSyncTime := d.Get("original_db_sync_updates_time").(string)
+		if len(originalDbSyncTime) == 0 {
+			d.SetId("")
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Original DB sync time is unknown",
+				Detail:   "The DB sync time was not captured when the resource was created, so it can't be restored. The actual DB sync time will remain unchanged.",
+			}}
+		}
+
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(DbSyncDailyUpdatesTime{DbSyncTime: originalDbSyncTime}).
+			Put("xray/api/v1/configuration/dbsync/time")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
@@ -2,16 +2,18 @@ package xray
 
 import (
 	"fmt"
-	"github.com/jfrog/terraform-provider-shared/test"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-shared/test"
 )
 
 func TestDbSyncTime(t *testing.T) {
 	_, fqrn, resourceName := test.MkNames("db_sync-", "xray_settings")
 	time := "18:45"
+	updatedTime := "19:30"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -19,7 +21,66 @@ func TestDbSyncTime(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: dbSyncTime(resourceName, time),
-				Check:  resource.TestCheckResourceAttr(fqrn, "db_sync_updates_time", time),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "id", "xray_settings"),
+					resource.TestCheckResourceAttr(fqrn, "db_sync_updates_time", time),
+				),
+			},
+			{
+				Config: dbSyncTime(resourceName, updatedTime),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "id", "xray_settings"),
+					resource.TestCheckResourceAttr(fqrn, "db_sync_updates_time", updatedTime),
+				),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateId:           "any-id",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"reset_on_destroy"},
+			},
+		},
+	})
+}
+
+func TestDbSyncTimeResetOnDestroy(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("db_sync-", "xray_settings")
+	var originalTime string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			dbSyncTime, _, err := getDBSyncTime(GetTestResty(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			originalTime = dbSyncTime.DbSyncTime
+		},
+		ProviderFactories: testAccProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			dbSyncTime, _, err := getDBSyncTime(GetTestResty(t))
+			if err != nil {
+				return err
+			}
+			if dbSyncTime.DbSyncTime != originalTime {
+				return fmt.Errorf("expected DB sync time to be restored to %s, got %s", originalTime, dbSyncTime.DbSyncTime)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "xray_settings" "%s" {
+						db_sync_updates_time = "03:15"
+						reset_on_destroy     = true
+					}
+				`, resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "db_sync_updates_time", "03:15"),
+					resource.TestCheckResourceAttrPtr(fqrn, "original_db_sync_updates_time", &originalTime),
+				),
 			},
 		},
 	})
@@ -21,7 +21,7 @@ func resourceXrayWatch() *schema.Resource {
 		CustomizeDiff: watchResourceDiff,
 
 		Schema: util.MergeMaps(
-			getProjectKeySchema(false, "Support repository and build watch resource types. When specifying individual repository or build they must be already assigned to the project. Build must be added as indexed resources."),
+			getProjectKeySchema(false, "Support repository and build watch resource types. When specifying individual repository or build they must be already assigned to the project. Build must be added as indexed resources, e.g. with the `xray_indexed_resources` resource."),
 			map[string]*schema.Schema{
 				"name": {
 					Type:             schema.TypeString,
@@ -56,7 +56,7 @@ func resourceXrayWatch() *schema.Resource {
 								Type:        schema.TypeString,
 								Optional:    true,
 								Default:     "default",
-								Description: "The ID number of a binary manager resource. Default value is `default`. To check the list of available binary managers, use the API call `${JFROG_URL}/xray/api/v1/binMgr` as an admin user, use `binMgrId` value. More info [here](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-GetBinaryManager)",
+								Description: "The ID number of a binary manager resource. Default value is `default`. To check the list of available binary managers, use the `xray_binary_managers` data source, or the API call `${JFROG_URL}/xray/api/v1/binMgr` as an admin user, use `binMgrId` value. The value is validated against the list of registered binary managers during the plan, if the list is available. More info [here](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-GetBinaryManager)",
 							},
 							"name": {
 								Type:        schema.TypeString,
@@ -899,6 +899,30 @@ func TestAccWatch_invalidProjectFilter(t *testing.T) {
 	})
 }
 
+func TestAccWatch_invalidBinaryManagerId(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("watch-", "xray_watch")
+	testData := util.MergeMaps(testDataWatch)
+
+	testData["resource_name"] = resourceName
+	testData["watch_name"] = fmt.Sprintf("xray-watch-%d", test.RandomInt())
+	testData["policy_name_0"] = fmt.Sprintf("xray-policy-%d", test.RandomInt())
+	testData["bin_mgr_id"] = fmt.Sprintf("fake-bin-mgr-%d", test.RandomInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy:      verifyDeleted(fqrn, testCheckWatch),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, invalidBinaryManagerIdWatchTemplate, testData),
+				ExpectError: regexp.MustCompile(`attribute 'bin_mgr_id' value '` + testData["bin_mgr_id"] + `' is not a registered binary manager`),
+			},
+		},
+	})
+}
+
 const allReposSinglePolicyWatchTemplate = `resource "xray_security_policy" "security" {
   name        = "{{ .policy_name_0 }}"
   description = "Security policy description"
@@ -1653,3 +1677,37 @@ func checkWatch(id string, request *resty.Request) (*resty.Response, error) {
 func testCheckWatch(id string, request *resty.Request) (*resty.Response, error) {
 	return checkWatch(id, request.AddRetryCondition(client.NeverRetry))
 }
+
+const invalidBinaryManagerIdWatchTemplate = `resource "xray_security_policy" "security" {
+  name        = "{{ .policy_name_0 }}"
+  description = "Security policy description"
+  type        = "security"
+  rule {
+    name     = "rule-name-severity"
+    priority = 1
+    criteria {
+      min_severity = "High"
+    }
+    actions {
+      block_download {
+        unscanned = true
+        active    = true
+      }
+    }
+  }
+}
+
+resource "xray_watch" "{{ .resource_name }}" {
+  name        	= "{{ .watch_name }}"
+  description 	= "{{ .description }}"
+  active 		= {{ .active }}
+
+  watch_resource {
+	type       	= "all-repos"
+	bin_mgr_id 	= "{{ .bin_mgr_id }}"
+  }
+  assigned_policy {
+  	name 	= xray_security_policy.security.name
+  	type 	= "security"
+  }
+}`
@@ -0,0 +1,238 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+type Webhook struct {
+	Name        string            `json:"name"`
+	Url         string            `json:"url"`
+	Description string            `json:"description,omitempty"`
+	UseProxy    bool              `json:"use_proxy"`
+	UserName    string            `json:"user_name,omitempty"`
+	Password    string            `json:"password,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+func resourceXrayWebhook() *schema.Resource {
+	var webhookSchema = map[string]*schema.Schema{
+		"name": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Name of the webhook (must be unique). Use this value in the `webhooks` attribute of the policy actions.",
+		},
+		"url": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+			Description:      "URL of the webhook. Xray will send a POST request with the violation details to this URL.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Description of the webhook.",
+		},
+		"use_proxy": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Use the proxy configured in Xray to reach the webhook URL. Default value is `false`.",
+		},
+		"user_name": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			RequiredWith:     []string{"password"},
+			Description:      "User name for basic authentication against the webhook URL.",
+		},
+		"password": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			RequiredWith:     []string{"user_name"},
+			Description:      "Password for basic authentication against the webhook URL. Xray does not return the password, so changes made outside of Terraform are not detected.",
+		},
+		"headers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Custom HTTP headers sent with each webhook call, e.g. an authorization token. Xray does not return the header values, so changes made outside of Terraform are not detected.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"test_on_apply": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Send a test call to the webhook URL after the webhook is created or updated. A failed test call is reported as a warning. Default value is `false`.",
+		},
+	}
+
+	var unpackWebhook = func(s *schema.ResourceData) Webhook {
+		d := &util.ResourceData{ResourceData: s}
+
+		webhook := Webhook{
+			Name:        d.GetString("name", false),
+			Url:         d.GetString("url", false),
+			Description: d.GetString("description", false),
+			UseProxy:    d.GetBool("use_proxy", false),
+			UserName:    d.GetString("user_name", false),
+			Password:    d.GetString("password", false),
+		}
+
+		if v, ok := s.GetOk("headers"); ok {
+			headers := map[string]string{}
+			for key, value := range v.(map[string]interface{}) {
+				headers[key] = value.(string)
+			}
+			webhook.Headers = headers
+		}
+
+		return webhook
+	}
+
+	// 'password' and 'headers' are not packed, as Xray doesn't return their values
+	var packWebhook = func(webhook Webhook, d *schema.ResourceData) diag.Diagnostics {
+		if err := d.Set("name", webhook.Name); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("url", webhook.Url); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("description", webhook.Description); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("use_proxy", webhook.UseProxy); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("user_name", webhook.UserName); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	var testWebhook = func(ctx context.Context, webhook Webhook, m interface{}) diag.Diagnostics {
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(webhook).
+			Post("xray/api/v1/webhooks/test")
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Test call for Xray webhook (%s) failed: %s", webhook.Name, err))
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Webhook test call failed",
+				Detail:   fmt.Sprintf("The webhook (%s) was saved, but the test call to %s failed: %s", webhook.Name, webhook.Url, err),
+			}}
+		}
+
+		return nil
+	}
+
+	var resourceXrayWebhookRead = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		webhook := Webhook{}
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetResult(&webhook).
+			SetPathParam("name", d.Id()).
+			Get("xray/api/v1/webhooks/{name}")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray webhook (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		return packWebhook(webhook, d)
+	}
+
+	var resourceXrayWebhookCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		webhook := unpackWebhook(d)
+
+		_, err := m.(ProviderMetadata).Client.R().
+			SetBody(webhook).
+			Post("xray/api/v1/webhooks")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(webhook.Name)
+
+		diags := resourceXrayWebhookRead(ctx, d, m)
+		if diags.HasError() {
+			return diags
+		}
+
+		if d.Get("test_on_apply").(bool) {
+			diags = append(diags, testWebhook(ctx, webhook, m)...)
+		}
+
+		return diags
+	}
+
+	var resourceXrayWebhookUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		webhook := unpackWebhook(d)
+
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetBody(webhook).
+			SetPathParam("name", d.Id()).
+			Put("xray/api/v1/webhooks/{name}")
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("Xray webhook (%s) not found, removing from state", d.Id()))
+				d.SetId("")
+			}
+			return diag.FromErr(err)
+		}
+
+		diags := resourceXrayWebhookRead(ctx, d, m)
+		if diags.HasError() {
+			return diags
+		}
+
+		if d.Get("test_on_apply").(bool) {
+			diags = append(diags, testWebhook(ctx, webhook, m)...)
+		}
+
+		return diags
+	}
+
+	var resourceXrayWebhookDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		resp, err := m.(ProviderMetadata).Client.R().
+			SetPathParam("name", d.Id()).
+			Delete("xray/api/v1/webhooks/{name}")
+		if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceXrayWebhookCreate,
+		ReadContext:   resourceXrayWebhookRead,
+		UpdateContext: resourceXrayWebhookUpdate,
+		DeleteContext: resourceXrayWebhookDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema:      webhookSchema,
+		Description: "Provides an Xray webhook resource. Webhooks are invoked by policy rules when a violation is triggered; reference the webhook `name` in the `webhooks` attribute of the policy `actions`. See [Xray Webhooks](https://www.jfrog.com/confluence/display/JFROG/Configuring+Xray+Webhooks) and [REST API](https://www.jfrog.com/confluence/display/JFROG/Xray+REST+API#XrayRESTAPI-WEBHOOKS) for more details.",
+	}
+}
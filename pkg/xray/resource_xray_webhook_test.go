@@ -0,0 +1,103 @@
+package xray
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const webhookTemplate = `
+	resource "xray_webhook" "{{ .resource_name }}" {
+	  name        = "{{ .webhook_name }}"
+	  url         = "{{ .url }}"
+	  description = "{{ .description }}"
+	  use_proxy   = false
+	  user_name   = "test-user"
+	  password    = "test-password"
+
+	  headers = {
+	    header1 = "value1"
+	  }
+	}
+`
+
+func TestAccWebhook_full(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("webhook-", "xray_webhook")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"webhook_name":  fmt.Sprintf("webhook-%d", test.RandomInt()),
+		"url":           "https://tempurl.org",
+		"description":   "webhook created by xray acceptance tests",
+	}
+	updatedTestData := util.MergeMaps(testData)
+	updatedTestData["url"] = "https://tempurl.org/updated"
+	updatedTestData["description"] = "updated webhook description"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		CheckDestroy:      verifyDeleted(fqrn, testCheckWebhook),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, webhookTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "name", testData["webhook_name"]),
+					resource.TestCheckResourceAttr(fqrn, "url", testData["url"]),
+					resource.TestCheckResourceAttr(fqrn, "description", testData["description"]),
+					resource.TestCheckResourceAttr(fqrn, "use_proxy", "false"),
+					resource.TestCheckResourceAttr(fqrn, "user_name", "test-user"),
+					resource.TestCheckResourceAttr(fqrn, "headers.%", "1"),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(fqrn, webhookTemplate, updatedTestData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "url", updatedTestData["url"]),
+					resource.TestCheckResourceAttr(fqrn, "description", updatedTestData["description"]),
+				),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "headers", "test_on_apply"},
+			},
+		},
+	})
+}
+
+func TestAccWebhook_invalidUrl(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("webhook-", "xray_webhook")
+
+	testData := map[string]string{
+		"resource_name": resourceName,
+		"webhook_name":  fmt.Sprintf("webhook-%d", test.RandomInt()),
+		"url":           "tempurl.org",
+		"description":   "webhook created by xray acceptance tests",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      util.ExecuteTemplate(fqrn, webhookTemplate, testData),
+				ExpectError: regexp.MustCompile(`expected "url" to have a host`),
+			},
+		},
+	})
+}
+
+func testCheckWebhook(id string, request *resty.Request) (*resty.Response, error) {
+	return request.
+		AddRetryCondition(client.NeverRetry).
+		SetPathParam("name", id).
+		Get("xray/api/v1/webhooks/{name}")
+}
@@ -6,31 +6,87 @@ import (
 	"fmt"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jfrog/terraform-provider-shared/util"
 )
 
-func resourceXrayWorkersCount() *schema.Resource {
-	newContentSchema := map[string]*schema.Schema{
+type WorkersCountLimit struct {
+	New int
+	// Zero, if the queue has no workers for existing content
+	Existing int
+}
+
+// Recommended maximum number of workers per queue. Xray doesn't enforce these limits, so the higher counts
+// only raise a warning.
+var maxWorkersCount = map[string]WorkersCountLimit{
+	"index":           {New: 32, Existing: 16},
+	"persist":         {New: 32, Existing: 16},
+	"analysis":        {New: 32, Existing: 16},
+	"alert":           {New: 32, Existing: 16},
+	"impact_analysis": {New: 16},
+	"notification":    {New: 16},
+}
+
+// workersCount requires at least one worker and warns about the counts above the recommended maximum
+func workersCount(max int) schema.SchemaValidateDiagFunc {
+	atLeastOne := validation.ToDiagFunc(validation.IntAtLeast(1))
+
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		if diags := atLeastOne(i, path); diags.HasError() {
+			return diags
+		}
+
+		if v, ok := i.(int); ok && v > max {
+			return diag.Diagnostics{{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("Workers count %d is above the recommended maximum of %d", v, max),
+				Detail:        "A high number of workers may exhaust the resources of the Xray server.",
+				AttributePath: path,
+			}}
+		}
+
+		return nil
+	}
+}
+
+func workersCountContentSchema(queue string) map[string]*schema.Schema {
+	limit := maxWorkersCount[queue]
+	contentSchema := map[string]*schema.Schema{
 		"new_content": {
-			Type:        schema.TypeInt,
-			Required:    true,
-			Description: "Number of workers for new content",
+			Type:             schema.TypeInt,
+			Required:         true,
+			ValidateDiagFunc: workersCount(limit.New),
+			Description:      fmt.Sprintf("Number of workers for new content. Must be at least 1, counts above %d raise a warning.", limit.New),
 		},
 	}
 
-	newExistingContentSchema := util.MergeMaps(
-		newContentSchema,
-		map[string]*schema.Schema{
-			"existing_content": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Number of workers for existing content",
-			},
-		},
-	)
+	if limit.Existing > 0 {
+		contentSchema["existing_content"] = &schema.Schema{
+			Type:             schema.TypeInt,
+			Required:         true,
+			ValidateDiagFunc: workersCount(limit.Existing),
+			Description:      fmt.Sprintf("Number of workers for existing content. Must be at least 1, counts above %d raise a warning.", limit.Existing),
+		}
+	}
+
+	return contentSchema
+}
+
+// The restart is required, if the apply changes any of the workers counts
+func workersCountRestartRequiredDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	for queue := range maxWorkersCount {
+		if diff.HasChange(queue) {
+			return diff.SetNewComputed("restart_required")
+		}
+	}
+
+	return nil
+}
 
+func resourceXrayWorkersCount() *schema.Resource {
 	workersCountSchema := map[string]*schema.Schema{
 		"index": {
 			Type:        schema.TypeSet,
@@ -39,7 +95,7 @@ func resourceXrayWorkersCount() *schema.Resource {
 			MaxItems:    1,
 			Description: "The number of workers managing indexing of artifacts.",
 			Elem: &schema.Resource{
-				Schema: newExistingContentSchema,
+				Schema: workersCountContentSchema("index"),
 			},
 		},
 		"persist": {
@@ -49,7 +105,7 @@ func resourceXrayWorkersCount() *schema.Resource {
 			MaxItems:    1,
 			Description: "The number of workers managing persistent storage needed to build the artifact relationship graph.",
 			Elem: &schema.Resource{
-				Schema: newExistingContentSchema,
+				Schema: workersCountContentSchema("persist"),
 			},
 		},
 		"alert": {
@@ -59,7 +115,7 @@ func resourceXrayWorkersCount() *schema.Resource {
 			MaxItems:    1,
 			Description: "The number of workers managing alerts.",
 			Elem: &schema.Resource{
-				Schema: newExistingContentSchema,
+				Schema: workersCountContentSchema("alert"),
 			},
 		},
 		"analysis": {
@@ -69,7 +125,7 @@ func resourceXrayWorkersCount() *schema.Resource {
 			MaxItems:    1,
 			Description: "The number of workers involved in scanning analysis.",
 			Elem: &schema.Resource{
-				Schema: newExistingContentSchema,
+				Schema: workersCountContentSchema("analysis"),
 			},
 		},
 		"impact_analysis": {
@@ -79,7 +135,7 @@ func resourceXrayWorkersCount() *schema.Resource {
 			MaxItems:    1,
 			Description: "The number of workers involved in Impact Analysis to determine how a component with a reported issue impacts others in the system.",
 			Elem: &schema.Resource{
-				Schema: newContentSchema,
+				Schema: workersCountContentSchema("impact_analysis"),
 			},
 		},
 		"notification": {
@@ -89,9 +145,14 @@ func resourceXrayWorkersCount() *schema.Resource {
 			MaxItems:    1,
 			Description: "The number of workers managing notifications.",
 			Elem: &schema.Resource{
-				Schema: newContentSchema,
+				Schema: workersCountContentSchema("notification"),
 			},
 		},
+		"restart_required": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Set to `true` when the last apply changed the workers count. Xray must be restarted to apply the changes. Only the changes applied by Terraform are reported, the attribute is not updated on import or refresh.",
+		},
 	}
 
 	type NewContent struct {
@@ -210,19 +271,17 @@ func resourceXrayWorkersCount() *schema.Resource {
 		}
 	}
 
-	var resourceXrayWorkersCountCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		return diag.Diagnostics{{
-			Severity: diag.Error,
-			Summary:  "Workers Count resource does not support create",
-			Detail:   "Workers Count can only be updated. To manage this resource in Terraform, use `terraform import` to import it into the state.",
-		}}
-	}
-
-	var resourceXrayWorkersCountRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var getWorkersCount = func(client *resty.Client) (WorkersCount, *resty.Response, error) {
 		workersCount := WorkersCount{}
-		resp, err := m.(*resty.Client).R().
+		resp, err := client.R().
 			SetResult(&workersCount).
 			Get("xray/api/v1/configuration/workersCount")
+
+		return workersCount, resp, err
+	}
+
+	var resourceXrayWorkersCountRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		workersCount, resp, err := getWorkersCount(m.(ProviderMetadata).Client)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -235,19 +294,35 @@ func resourceXrayWorkersCount() *schema.Resource {
 
 	var resourceXrayWorkersCountUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		workersCount := unpackWorkersCount(d)
-		_, err := m.(*resty.Client).R().
-			SetBody(workersCount).
-			Put("xray/api/v1/configuration/workersCount")
 
+		currentWorkersCount, _, err := getWorkersCount(m.(ProviderMetadata).Client)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
+		restartRequired := currentWorkersCount != workersCount
+		if restartRequired {
+			_, err = m.(ProviderMetadata).Client.R().
+				SetBody(workersCount).
+				Put("xray/api/v1/configuration/workersCount")
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if err := d.Set("restart_required", restartRequired); err != nil {
+			return diag.FromErr(err)
+		}
+
 		diagnostic := resourceXrayWorkersCountRead(ctx, d, m)
 		if diagnostic != nil {
 			return diagnostic
 		}
 
+		if !restartRequired {
+			return nil
+		}
+
 		return diag.Diagnostics{{
 			Severity: diag.Warning,
 			Summary:  "Xray must be restarted",
@@ -255,25 +330,30 @@ func resourceXrayWorkersCount() *schema.Resource {
 		}}
 	}
 
+	// No delete functionality provided by API, the workers count is only removed from the Terraform state
 	var resourceXrayWorkersCountDelete = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d.SetId("")
+
 		return diag.Diagnostics{{
-			Severity: diag.Error,
+			Severity: diag.Warning,
 			Summary:  "Workers Count resource does not support delete",
-			Detail:   "Workers Count can only be updated. To stop managing this resource in Terraform, use `terraform state rm` to removed it from the state. Then the resource can be removed from the configuration.",
+			Detail:   "Workers Count can only be updated. The resource is removed from the Terraform state, the actual workers count will remain unchanged.",
 		}}
 	}
 
+	// The workers count always exists in Xray, so create adopts it and applies the configured values
 	return &schema.Resource{
-		CreateContext: resourceXrayWorkersCountCreate,
+		CreateContext: resourceXrayWorkersCountUpdate,
 		ReadContext:   resourceXrayWorkersCountRead,
 		UpdateContext: resourceXrayWorkersCountUpdate,
 		DeleteContext: resourceXrayWorkersCountDelete,
-		Description:   "Configure the number of workers which enables you to control the number of workers for new content and existing content. Only works for self-hosted version!",
+		Description:   "Configure the number of workers which enables you to control the number of workers for new content and existing content. The resource adopts the current configuration on create. Only works for self-hosted version!",
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: workersCountSchema,
+		Schema:        workersCountSchema,
+		CustomizeDiff: workersCountRestartRequiredDiff,
 	}
 }
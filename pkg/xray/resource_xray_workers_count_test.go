@@ -4,13 +4,15 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/jfrog/terraform-provider-shared/test"
 	"github.com/jfrog/terraform-provider-shared/util"
 )
 
 func TestAccWorkersCount_create(t *testing.T) {
-	_, _, resourceName := test.MkNames("workers-count-", "xray_workers_count")
+	_, fqrn, resourceName := test.MkNames("workers-count-", "xray_workers_count")
 
 	params := map[string]interface{}{
 		"workersCountName": resourceName,
@@ -42,14 +44,82 @@ func TestAccWorkersCount_create(t *testing.T) {
 		}
 	`, params)
 
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: workersCountConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "index.0.new_content", "4"),
+					resource.TestCheckResourceAttr(fqrn, "index.0.existing_content", "2"),
+					resource.TestCheckResourceAttr(fqrn, "notification.0.new_content", "2"),
+					resource.TestCheckResourceAttrSet(fqrn, "restart_required"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWorkersCount_invalidCount(t *testing.T) {
+	_, _, resourceName := test.MkNames("workers-count-", "xray_workers_count")
+
+	params := map[string]interface{}{
+		"workersCountName": resourceName,
+	}
+	workersCountConfig := util.ExecuteTemplate("TestAccWorkersCount_invalidCount", `
+		resource "xray_workers_count" "{{ .workersCountName }}" {
+		  index {
+		    new_content      = 0
+		    existing_content = 2
+		  }
+		  persist {
+		    new_content      = 4
+		    existing_content = 2
+		  }
+		  analysis {
+		    new_content      = 4
+		    existing_content = 2
+		  }
+		  alert {
+		    new_content      = 4
+		    existing_content = 2
+		  }
+		  impact_analysis {
+		    new_content = 2
+		  }
+		  notification {
+		    new_content = 2
+		  }
+		}
+	`, params)
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProviders(),
 		Steps: []resource.TestStep{
 			{
 				Config:      workersCountConfig,
-				ExpectError: regexp.MustCompile(`Workers Count resource does not support create`),
+				ExpectError: regexp.MustCompile(`expected new_content to be at least \(1\), got 0`),
 			},
 		},
 	})
 }
+
+func TestWorkersCount(t *testing.T) {
+	validate := workersCount(16)
+	path := cty.GetAttrPath("new_content")
+
+	if diags := validate(16, path); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for the recommended maximum, got: %v", diags)
+	}
+
+	if diags := validate(0, path); !diags.HasError() {
+		t.Errorf("expected an error for zero workers, got: %v", diags)
+	}
+
+	diags := validate(17, path)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a single warning above the recommended maximum, got: %v", diags)
+	}
+}
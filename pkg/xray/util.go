@@ -2,18 +2,20 @@ package xray
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
-func getRestyRequest(client *resty.Client, projectKey string) (*resty.Request, error) {
-	if client == nil {
+func getRestyRequest(meta ProviderMetadata, projectKey string) (*resty.Request, error) {
+	if meta.Client == nil {
 		return nil, fmt.Errorf("client is nil")
 	}
 
-	req := client.R()
+	req := meta.Client.R()
 	if len(projectKey) > 0 {
 		req = req.SetQueryParam("projectKey", projectKey)
 	}
@@ -21,6 +23,79 @@ func getRestyRequest(client *resty.Client, projectKey string) (*resty.Request, e
 	return req, nil
 }
 
+type XrayVersion struct {
+	Version  string `json:"xray_version"`
+	Revision string `json:"xray_revision"`
+}
+
+func getXrayVersion(client *resty.Client) (string, error) {
+	xrayVersion := XrayVersion{}
+
+	_, err := client.R().
+		SetResult(&xrayVersion).
+		Get("xray/api/v1/system/version")
+	if err != nil {
+		return "", err
+	}
+
+	return xrayVersion.Version, nil
+}
+
+type XrayPing struct {
+	Status string `json:"status"`
+}
+
+func pingXray(client *resty.Client) error {
+	ping := XrayPing{}
+
+	_, err := client.R().
+		SetResult(&ping).
+		Get("xray/api/v1/system/ping")
+	if err != nil {
+		return err
+	}
+	if ping.Status != "pong" {
+		return fmt.Errorf("unexpected Xray status %q", ping.Status)
+	}
+
+	return nil
+}
+
+type XrayEntitlement struct {
+	FeatureId string `json:"feature_id"`
+	Entitled  bool   `json:"entitled"`
+}
+
+func getXrayEntitlement(client *resty.Client, featureId string) (bool, error) {
+	entitlement := XrayEntitlement{}
+
+	_, err := client.R().
+		SetResult(&entitlement).
+		SetPathParam("feature_id", featureId).
+		Get("xray/api/v1/entitlements/feature/{feature_id}")
+
+	return entitlement.Entitled, err
+}
+
+// checkXrayVersion returns an error, if the feature requires a newer Xray than the current one.
+// The check is skipped, if the current version is unknown or can't be parsed.
+func checkXrayVersion(xrayVersion, minVersion, feature string) error {
+	if xrayVersion == "" {
+		return nil
+	}
+
+	currentVersion, err := version.NewVersion(xrayVersion)
+	if err != nil {
+		return nil
+	}
+
+	if currentVersion.LessThan(version.Must(version.NewVersion(minVersion))) {
+		return fmt.Errorf("%s requires Xray version %s or later, current version is %s", feature, minVersion, xrayVersion)
+	}
+
+	return nil
+}
+
 var getProjectKeySchema = func(isForceNew bool, additionalDescription string) map[string]*schema.Schema {
 	description := fmt.Sprintf("Project key for assigning this resource to. Must be 3 - 10 lowercase alphanumeric and hyphen characters. %s", additionalDescription)
 
@@ -34,3 +109,75 @@ var getProjectKeySchema = func(isForceNew bool, additionalDescription string) ma
 		},
 	}
 }
+
+// ignoreCaseDiffSuppress suppresses the diff of the enum values, which are validated case-insensitively,
+// so the value normalized by Xray doesn't cause a perpetual diff
+func ignoreCaseDiffSuppress(_, old, new string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// antPatternMatch reports whether the path matches the Ant-style pattern.
+// '?' matches one character, '*' matches zero or more characters within a path segment,
+// and '**' matches zero or more path segments. A trailing '/' is the same as '/**'.
+func antPatternMatch(pattern, path string) bool {
+	return matchAntSegments(splitAntPattern(pattern), strings.Split(strings.Trim(path, "/"), "/"), coverAntSegment)
+}
+
+// antPatternCovers reports whether every path matched by 'other' is also matched by 'pattern'.
+// The check is conservative: it may return false for some patterns, which do cover 'other', but never the opposite.
+func antPatternCovers(pattern, other string) bool {
+	return matchAntSegments(splitAntPattern(pattern), splitAntPattern(other), func(patternSegment, otherSegment string) bool {
+		// '**' can only be covered by '**', which is handled by matchAntSegments
+		return otherSegment != "**" && coverAntSegment(patternSegment, otherSegment)
+	})
+}
+
+func splitAntPattern(pattern string) []string {
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	return strings.Split(pattern, "/")
+}
+
+func matchAntSegments(patternSegments, segments []string, matchSegment func(patternSegment, segment string) bool) bool {
+	if len(patternSegments) == 0 {
+		return len(segments) == 0
+	}
+
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchAntSegments(patternSegments[1:], segments[i:], matchSegment) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return len(segments) > 0 &&
+		matchSegment(patternSegments[0], segments[0]) &&
+		matchAntSegments(patternSegments[1:], segments[1:], matchSegment)
+}
+
+// coverAntSegment matches the segment against the pattern segment. Wildcards in 'segment' are
+// matched as regular characters by '*' only, so a pattern segment covers a segment with wildcards
+// only if it matches all the possible expansions.
+func coverAntSegment(pattern, segment string) bool {
+	if len(pattern) == 0 {
+		return len(segment) == 0
+	}
+
+	switch pattern[0] {
+	case '*':
+		for i := 0; i <= len(segment); i++ {
+			if coverAntSegment(pattern[1:], segment[i:]) {
+				return true
+			}
+		}
+		return false
+	case '?':
+		return len(segment) > 0 && segment[0] != '*' && coverAntSegment(pattern[1:], segment[1:])
+	default:
+		return len(segment) > 0 && segment[0] == pattern[0] && coverAntSegment(pattern[1:], segment[1:])
+	}
+}
@@ -2,8 +2,10 @@ package xray
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
@@ -38,7 +40,7 @@ func verifyDeleted(id string, check CheckFun) func(*terraform.State) error {
 		}
 		provider, _ := testAccProviders()["xray"]()
 		provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil))
-		c := provider.Meta().(*resty.Client)
+		c := provider.Meta().(ProviderMetadata).Client
 		resp, err := check(rs.Primary.ID, c.R())
 		if err != nil {
 			if resp != nil {
@@ -110,3 +112,128 @@ func DeleteProject(t *testing.T, projectKey string) {
 		t.Fatal(err)
 	}
 }
+
+func TestAntPatternMatch(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"core/**", "core/lib/app.jar", true},
+		{"core/**", "core", true},
+		{"core/", "core/lib/app.jar", true},
+		{"core/*.jar", "core/app.jar", true},
+		{"core/*.jar", "core/lib/app.jar", false},
+		{"**/*.jar", "core/lib/app.jar", true},
+		{"core/**/app.jar", "core/app.jar", true},
+		{"core/?pp.jar", "core/app.jar", true},
+		{"core/?pp.jar", "core/pp.jar", false},
+		{"docs/**", "core/lib/app.jar", false},
+	}
+
+	for _, testCase := range testCases {
+		if actual := antPatternMatch(testCase.pattern, testCase.path); actual != testCase.expected {
+			t.Errorf("antPatternMatch(%q, %q): expected %t, got %t", testCase.pattern, testCase.path, testCase.expected, actual)
+		}
+	}
+}
+
+func TestAntPatternCovers(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		other    string
+		expected bool
+	}{
+		{"**", "core/**", true},
+		{"core/**", "core/lib/**", true},
+		{"core/**", "core/*.jar", true},
+		{"core/*", "core/*.jar", true},
+		{"core/*.jar", "core/*", false},
+		{"core/*", "core/**", false},
+		{"core/?.jar", "core/*.jar", false},
+		{"core/lib/**", "core/**", false},
+		{"core/**", "docs/**", false},
+	}
+
+	for _, testCase := range testCases {
+		if actual := antPatternCovers(testCase.pattern, testCase.other); actual != testCase.expected {
+			t.Errorf("antPatternCovers(%q, %q): expected %t, got %t", testCase.pattern, testCase.other, testCase.expected, actual)
+		}
+	}
+}
+
+func TestAntPatternNegative(t *testing.T) {
+	for _, pattern := range []string{"", "/core/**", "core//lib", "core/lib**", "core\\lib"} {
+		if diags := antPattern(pattern, nil); !diags.HasError() {
+			t.Errorf("expected pattern %q to be invalid", pattern)
+		}
+	}
+}
+
+// Response of the test Xray server, the body is encoded as JSON
+type testXrayResponse struct {
+	status int
+	body   interface{}
+}
+
+// testXrayClient returns the client of the test server, which replies to the requested paths with the responses,
+// and to any other path with 404
+func testXrayClient(t *testing.T, responses map[string]testXrayResponse) *resty.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(response.status)
+		_ = json.NewEncoder(w).Encode(response.body)
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, productId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return restyClient.SetRetryCount(0)
+}
+
+func TestPingXray(t *testing.T) {
+	testCases := []struct {
+		response    testXrayResponse
+		expectError bool
+	}{
+		{testXrayResponse{http.StatusOK, XrayPing{Status: "pong"}}, false},
+		{testXrayResponse{http.StatusOK, XrayPing{Status: "starting"}}, true},
+		{testXrayResponse{http.StatusServiceUnavailable, nil}, true},
+	}
+
+	for _, testCase := range testCases {
+		restyClient := testXrayClient(t, map[string]testXrayResponse{
+			"/xray/api/v1/system/ping": testCase.response,
+		})
+		if err := pingXray(restyClient); (err != nil) != testCase.expectError {
+			t.Errorf("pingXray for %+v: expected error %t, got %v", testCase.response, testCase.expectError, err)
+		}
+	}
+}
+
+func TestGetXrayEntitlement(t *testing.T) {
+	restyClient := testXrayClient(t, map[string]testXrayResponse{
+		"/xray/api/v1/entitlements/feature/contextual_analysis": {http.StatusOK, XrayEntitlement{FeatureId: "contextual_analysis", Entitled: true}},
+		"/xray/api/v1/entitlements/feature/exposures":           {http.StatusOK, XrayEntitlement{FeatureId: "exposures", Entitled: false}},
+	})
+
+	if entitled, err := getXrayEntitlement(restyClient, "contextual_analysis"); err != nil || !entitled {
+		t.Errorf("expected 'contextual_analysis' to be entitled, got %t, %v", entitled, err)
+	}
+	if entitled, err := getXrayEntitlement(restyClient, "exposures"); err != nil || entitled {
+		t.Errorf("expected 'exposures' not to be entitled, got %t, %v", entitled, err)
+	}
+	// The entitlements API isn't supported by the older Xray versions
+	if _, err := getXrayEntitlement(restyClient, "secrets"); err == nil {
+		t.Error("expected an error for the unknown feature")
+	}
+}
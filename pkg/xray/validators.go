@@ -1,11 +1,74 @@
 package xray
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
 var matchesHoursMinutesTime = validation.ToDiagFunc(
 	validation.StringMatch(regexp.MustCompile(`^([0-1][0-9]|[2][0-3]):([0-5][0-9])$`), "Wrong format input, expected valid hour:minutes (HH:mm) form"),
 )
+
+// antPattern validates the syntax of the Ant-style path pattern, used by Xray to match artifact paths in a repository.
+var antPattern = validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if len(v) == 0 {
+		return nil, []error{fmt.Errorf("expected %q not to be an empty string", k)}
+	}
+
+	if strings.Contains(v, "\\") {
+		return nil, []error{fmt.Errorf("expected %q to use '/' as path separator, got: %s", k, v)}
+	}
+
+	for _, segment := range strings.Split(strings.TrimSuffix(v, "/"), "/") {
+		if len(segment) == 0 {
+			return nil, []error{fmt.Errorf("expected %q to be a relative path without empty segments, got: %s", k, v)}
+		}
+		if strings.Contains(segment, "**") && segment != "**" {
+			return nil, []error{fmt.Errorf("expected %q to use '**' only as a whole path segment, got: %s", k, v)}
+		}
+	}
+
+	return nil, nil
+})
+
+// versionRange validates the version constraints, e.g. '< 4.17.21' or '>= 1.0, < 2.0'
+var versionRange = validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if _, err := version.NewConstraint(v); err != nil {
+		return nil, []error{fmt.Errorf("expected %q to be a version range, e.g. '< 4.17.21' or '>= 1.0, < 2.0', got: %s", k, v)}
+	}
+
+	return nil, nil
+})
+
+// licenseName validates the license against the SPDX license list. Xray accepts the names of the custom licenses
+// as well, which can't be known while validating the configuration, so the unknown names only raise a warning
+func licenseName(i interface{}, path cty.Path) diag.Diagnostics {
+	diags := validator.LicenseType(i, path)
+	if !diags.HasError() {
+		return diags
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       fmt.Sprintf("%v is not a known SPDX license", i),
+		Detail:        "The license must be the name of a custom license created with `xray_custom_license`, otherwise Xray rejects the policy.",
+		AttributePath: path,
+	}}
+}
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -337,7 +336,7 @@ func packWatch(ctx context.Context, watch Watch, d *schema.ResourceData) diag.Di
 func resourceXrayWatchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	watch := unpackWatch(d)
 
-	req, err := getRestyRequest(m.(*resty.Client), watch.ProjectKey)
+	req, err := getRestyRequest(m.(ProviderMetadata), watch.ProjectKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -367,7 +366,7 @@ func resourceXrayWatchRead(ctx context.Context, d *schema.ResourceData, m interf
 	watch := Watch{}
 
 	projectKey := d.Get("project_key").(string)
-	req, err := getRestyRequest(m.(*resty.Client), projectKey)
+	req, err := getRestyRequest(m.(ProviderMetadata), projectKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -392,7 +391,7 @@ func resourceXrayWatchRead(ctx context.Context, d *schema.ResourceData, m interf
 func resourceXrayWatchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	watch := unpackWatch(d)
 
-	req, err := getRestyRequest(m.(*resty.Client), watch.ProjectKey)
+	req, err := getRestyRequest(m.(ProviderMetadata), watch.ProjectKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -418,7 +417,7 @@ func resourceXrayWatchUpdate(ctx context.Context, d *schema.ResourceData, m inte
 func resourceXrayWatchDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	watch := unpackWatch(d)
 
-	req, err := getRestyRequest(m.(*resty.Client), watch.ProjectKey)
+	req, err := getRestyRequest(m.(ProviderMetadata), watch.ProjectKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -435,11 +434,12 @@ func resourceXrayWatchDelete(_ context.Context, d *schema.ResourceData, m interf
 	return nil
 }
 
-func watchResourceDiff(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+func watchResourceDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	watchResources := diff.Get("watch_resource").(*schema.Set).List()
 	if len(watchResources) == 0 {
 		return nil
 	}
+	var binaryManagerIds []string
 	for _, watchResource := range watchResources {
 		r := watchResource.(map[string]interface{})
 		resourceType := r["type"].(string)
@@ -461,6 +461,43 @@ func watchResourceDiff(_ context.Context, diff *schema.ResourceDiff, v interface
 		if !slices.Contains(pathAntPatternsResourceTypes, resourceType) && len(pathAntFilters) > 0 {
 			return fmt.Errorf("attribute 'path_ant_filter' is set when 'watch_resource.type' is not set to 'repository' or 'all-repos'")
 		}
+
+		// bin_mgr_id may be unknown during the plan, e.g. when it comes from the xray_binary_managers data source
+		if binaryManagerId := r["bin_mgr_id"].(string); len(binaryManagerId) > 0 && !slices.Contains(binaryManagerIds, binaryManagerId) {
+			binaryManagerIds = append(binaryManagerIds, binaryManagerId)
+		}
 	}
+
+	return validateBinaryManagerIds(ctx, binaryManagerIds, v)
+}
+
+// validateBinaryManagerIds verifies the binary manager IDs against the list registered in Xray.
+// The check is skipped if the list is not available, e.g. when the user doesn't have admin permissions.
+func validateBinaryManagerIds(ctx context.Context, binaryManagerIds []string, m interface{}) error {
+	if len(binaryManagerIds) == 0 || m == nil {
+		return nil
+	}
+
+	meta := m.(ProviderMetadata)
+	binaryManagers, err := cachedLookup(meta.Cache, "binary_managers", func() ([]BinaryManager, error) {
+		binaryManagers, _, err := getBinaryManagers(meta.Client)
+		return binaryManagers, err
+	})
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("failed to get the list of binary managers, skipping 'bin_mgr_id' validation: %s", err))
+		return nil
+	}
+
+	var registeredIds []string
+	for _, binaryManager := range binaryManagers {
+		registeredIds = append(registeredIds, binaryManager.Id)
+	}
+
+	for _, binaryManagerId := range binaryManagerIds {
+		if !slices.Contains(registeredIds, binaryManagerId) {
+			return fmt.Errorf("attribute 'bin_mgr_id' value '%s' is not a registered binary manager. Available binary managers: %v", binaryManagerId, registeredIds)
+		}
+	}
+
 	return nil
 }